@@ -0,0 +1,107 @@
+package alert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Mode selects the sound played when a session completes
+type Mode int
+
+const (
+	ModeBell   Mode = iota // Single terminal bell
+	ModeTriple             // Three bells in quick succession
+	ModeNone               // Silent
+)
+
+// tripleGap is the pause between bells in the triple pattern
+const tripleGap = 300 * time.Millisecond
+
+// Output is where bell characters are written
+var Output io.Writer = os.Stdout
+
+// String returns the name used in BEOT_ALERT and the saved setting
+func (m Mode) String() string {
+	switch m {
+	case ModeTriple:
+		return "triple"
+	case ModeNone:
+		return "none"
+	default:
+		return "bell"
+	}
+}
+
+// Label returns a human-readable description for the settings screen
+func (m Mode) Label() string {
+	switch m {
+	case ModeTriple:
+		return "Triple bell"
+	case ModeNone:
+		return "Silent"
+	default:
+		return "Terminal bell"
+	}
+}
+
+// Next returns the mode after m in the settings cycle
+func (m Mode) Next() Mode {
+	switch m {
+	case ModeBell:
+		return ModeTriple
+	case ModeTriple:
+		return ModeNone
+	default:
+		return ModeBell
+	}
+}
+
+// Parse converts a name into a Mode, reporting whether it was recognised
+func Parse(s string) (Mode, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "bell":
+		return ModeBell, true
+	case "triple":
+		return ModeTriple, true
+	case "none", "off", "silent":
+		return ModeNone, true
+	}
+	return ModeBell, false
+}
+
+// Override returns the mode forced by BEOT_ALERT, if it is set and valid.
+// The variable is read on each call so values loaded from .env are seen.
+func Override() (Mode, bool) {
+	return Parse(os.Getenv("BEOT_ALERT"))
+}
+
+// Resolve picks the effective mode: BEOT_ALERT wins, then the saved setting,
+// then the single bell
+func Resolve(saved string) Mode {
+	if mode, ok := Override(); ok {
+		return mode
+	}
+	mode, _ := Parse(saved)
+	return mode
+}
+
+// Play sounds the alert for mode. It returns immediately; patterns with
+// gaps run in the background so the event loop is never blocked.
+func Play(mode Mode) {
+	switch mode {
+	case ModeBell:
+		fmt.Fprint(Output, "\a")
+	case ModeTriple:
+		go func() {
+			for i := 0; i < 3; i++ {
+				if i > 0 {
+					time.Sleep(tripleGap)
+				}
+				fmt.Fprint(Output, "\a")
+			}
+		}()
+	}
+}
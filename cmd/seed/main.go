@@ -1,14 +1,20 @@
 package main
 
 import (
-	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 
+	"github.com/rivo/uniseg"
+
 	"Beot/db"
 )
 
+// out receives progress messages; stderr when stdout carries a JSON report
+var out io.Writer = os.Stdout
+
 var seedQuotes = []struct {
 	Text     string
 	Source   string
@@ -123,6 +129,7 @@ var seedPoems = []struct {
 	ModernEnglish string
 	Source        string
 	LineRef       string
+	Subjects      []string // Empty = general passage for all subjects
 }{
 	// The Wanderer passages
 	{
@@ -213,93 +220,230 @@ var seedPoems = []struct {
 }
 
 func main() {
-	// Check for --clean flag
-	cleanMode := false
-	for _, arg := range os.Args[1:] {
-		if arg == "--clean" {
-			cleanMode = true
-		}
+	cleanMode := flag.Bool("clean", false, "drop quotes, subjects and poems before seeding")
+	dryRun := flag.Bool("dry-run", false, "print what would be added without writing anything")
+	only := flag.String("only", "", "seed a single collection: quotes, poems or subjects")
+	reportFormat := flag.String("report", "", "after seeding, print counts and a sample of each collection: json")
+	flag.Parse()
+
+	switch *reportFormat {
+	case "":
+	case "json":
+		out = os.Stderr
+	default:
+		log.Fatalf("Unknown -report value %q (want json)", *reportFormat)
 	}
 
-	fmt.Println("Connecting to MongoDB...")
-	if err := db.Connect(); err != nil {
+	switch *only {
+	case "", "quotes", "poems", "subjects":
+	default:
+		log.Fatalf("Unknown -only value %q (want quotes, poems or subjects)", *only)
+	}
+	want := func(name string) bool { return *only == "" || *only == name }
+
+	fmt.Fprintf(out, "Connecting to %s storage...\n", db.Backend())
+	if err := db.Open(); err != nil {
 		log.Fatalf("Failed to connect: %v", err)
 	}
-	defer db.Disconnect()
-
-	if cleanMode {
-		fmt.Println("Cleaning existing data...")
-		db.Database.Collection("quotes").Drop(context.Background())
-		db.Database.Collection("subjects").Drop(context.Background())
-		db.Database.Collection("poems").Drop(context.Background())
-		fmt.Println("Collections dropped.")
+	defer db.Close()
+	if db.MigrationErr != nil {
+		log.Printf("Warning: %v", db.MigrationErr)
+	}
+
+	if *dryRun {
+		fmt.Fprintln(out, "Dry run: nothing will be written.")
 	}
 
-	fmt.Println("Seeding quotes...")
+	if *cleanMode {
+		if *dryRun {
+			fmt.Fprintln(out, "Would drop the quotes, subjects and poems collections.")
+		} else {
+			fmt.Fprintln(out, "Cleaning existing data...")
+			if err := db.ClearContent(); err != nil {
+				log.Fatalf("Failed to clean: %v", err)
+			}
+			fmt.Fprintln(out, "Collections dropped.")
+		}
+	}
 
-	quotesAdded := 0
-	for _, q := range seedQuotes {
-		_, added, err := db.AddQuoteIfNotExists(q.Text, q.Source, q.Subjects)
+	// After a clean, a dry run compares against empty collections
+	assumeEmpty := *dryRun && *cleanMode
+
+	if want("quotes") {
+		seedQuoteData(*dryRun, assumeEmpty)
+	}
+	if want("subjects") {
+		seedSubjectData(*dryRun, assumeEmpty)
+	}
+	if want("poems") {
+		seedPoemData(*dryRun, assumeEmpty)
+	}
+
+	if *reportFormat == "json" {
+		if err := writeJSONReport(os.Stdout); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	}
+}
+
+// report prints one seed entry as added, would-add or already present
+func report(added, dryRun bool, description string) {
+	switch {
+	case added && dryRun:
+		fmt.Fprintf(out, "  Would add: %s\n", description)
+	case added:
+		fmt.Fprintf(out, "  Added: %s\n", description)
+	case dryRun:
+		fmt.Fprintf(out, "  Exists: %s\n", description)
+	}
+}
+
+func seedQuoteData(dryRun, assumeEmpty bool) {
+	fmt.Fprintln(out, "\nSeeding quotes...")
+
+	// Dry runs mirror AddQuoteIfNotExists, which ignores case, punctuation and spacing
+	existing := map[string]bool{}
+	if dryRun && !assumeEmpty {
+		quotes, err := db.GetAllQuotes()
 		if err != nil {
-			log.Printf("Failed to add quote: %v", err)
-			continue
+			log.Fatalf("Failed to load quotes: %v", err)
+		}
+		for _, q := range quotes {
+			existing[db.QuoteMatchKey(q.Text)] = true
+		}
+	}
+
+	quotesAdded, failed := 0, 0
+	for _, q := range seedQuotes {
+		added := !existing[db.QuoteMatchKey(q.Text)]
+		if !dryRun {
+			var err error
+			_, added, err = db.AddQuoteIfNotExists(q.Text, q.Source, q.Subjects)
+			if err != nil {
+				log.Printf("Failed to add quote: %v", err)
+				failed++
+				continue
+			}
 		}
 		if added {
 			quotesAdded++
-			subjectInfo := "general"
-			if len(q.Subjects) > 0 {
-				subjectInfo = fmt.Sprintf("%v", q.Subjects)
-			}
-			fmt.Printf("  Added [%s]: %s...\n", subjectInfo, truncate(q.Text, 40))
 		}
+		subjectInfo := "general"
+		if len(q.Subjects) > 0 {
+			subjectInfo = fmt.Sprintf("%v", q.Subjects)
+		}
+		report(added, dryRun, fmt.Sprintf("[%s]: %s", subjectInfo, truncate(q.Text, 40)))
+	}
+
+	skipped := len(seedQuotes) - quotesAdded - failed
+	if dryRun {
+		fmt.Fprintf(out, "Would add %d new quotes (%d already exist)\n", quotesAdded, skipped)
+		return
 	}
-	fmt.Printf("Added %d new quotes\n", quotesAdded)
+	fmt.Fprintf(out, "Added %d new quotes (%d skipped as existing, %d failed)\n", quotesAdded, skipped, failed)
 
 	count, _ := db.CountQuotes()
-	fmt.Printf("Total quotes in database: %d\n", count)
+	fmt.Fprintf(out, "Total quotes in database: %d\n", count)
+}
 
-	fmt.Println("\nSeeding subjects...")
+func seedSubjectData(dryRun, assumeEmpty bool) {
+	fmt.Fprintln(out, "\nSeeding subjects...")
 
-	subjectsAdded := 0
-	for _, s := range seedSubjects {
-		subject, added, err := db.AddSubjectIfNotExists(s.Name, s.Icon)
+	// Dry runs mirror AddSubjectIfNotExists, which matches on name
+	existing := map[string]bool{}
+	if dryRun && !assumeEmpty {
+		subjects, err := db.GetSubjects(true)
 		if err != nil {
-			log.Printf("Failed to add subject: %v", err)
-			continue
+			log.Fatalf("Failed to load subjects: %v", err)
+		}
+		for _, s := range subjects {
+			existing[s.Name] = true
+		}
+	}
+
+	subjectsAdded, failed := 0, 0
+	for _, s := range seedSubjects {
+		added := !existing[s.Name]
+		if !dryRun {
+			var err error
+			_, added, err = db.AddSubjectIfNotExists(s.Name, s.Icon)
+			if err != nil {
+				log.Printf("Failed to add subject: %v", err)
+				failed++
+				continue
+			}
 		}
 		if added {
 			subjectsAdded++
-			fmt.Printf("  Added: %s %s\n", subject.Icon, subject.Name)
 		}
+		report(added, dryRun, fmt.Sprintf("%s %s", s.Icon, s.Name))
 	}
-	fmt.Printf("Added %d new subjects\n", subjectsAdded)
 
-	subjects, _ := db.GetAllSubjects()
-	fmt.Printf("Total subjects in database: %d\n", len(subjects))
+	skipped := len(seedSubjects) - subjectsAdded - failed
+	if dryRun {
+		fmt.Fprintf(out, "Would add %d new subjects (%d already exist)\n", subjectsAdded, skipped)
+		return
+	}
+	fmt.Fprintf(out, "Added %d new subjects (%d skipped as existing, %d failed)\n", subjectsAdded, skipped, failed)
 
-	fmt.Println("\nSeeding poems...")
+	subjects, _ := db.GetSubjects(true)
+	fmt.Fprintf(out, "Total subjects in database: %d\n", len(subjects))
+}
 
-	poemsAdded := 0
-	for _, p := range seedPoems {
-		_, added, err := db.AddPoemIfNotExists(p.OldEnglish, p.ModernEnglish, p.Source, p.LineRef)
+func seedPoemData(dryRun, assumeEmpty bool) {
+	fmt.Fprintln(out, "\nSeeding poems...")
+
+	// Dry runs mirror AddPoemIfNotExists, which matches on source and line reference
+	existing := map[string]bool{}
+	if dryRun && !assumeEmpty {
+		poems, err := db.GetAllPoems()
 		if err != nil {
-			log.Printf("Failed to add poem: %v", err)
-			continue
+			log.Fatalf("Failed to load poems: %v", err)
+		}
+		for _, p := range poems {
+			existing[p.Source+"\x00"+p.LineRef] = true
+		}
+	}
+
+	poemsAdded, failed := 0, 0
+	for _, p := range seedPoems {
+		added := !existing[p.Source+"\x00"+p.LineRef]
+		if !dryRun {
+			var err error
+			_, added, err = db.AddPoemIfNotExists(p.OldEnglish, p.ModernEnglish, p.Source, p.LineRef, p.Subjects)
+			if err != nil {
+				log.Printf("Failed to add poem: %v", err)
+				failed++
+				continue
+			}
 		}
 		if added {
 			poemsAdded++
-			fmt.Printf("  Added: %s (%s)\n", p.Source, p.LineRef)
 		}
+		report(added, dryRun, fmt.Sprintf("%s (%s)", p.Source, p.LineRef))
 	}
-	fmt.Printf("Added %d new poems\n", poemsAdded)
+
+	skipped := len(seedPoems) - poemsAdded - failed
+	if dryRun {
+		fmt.Fprintf(out, "Would add %d new poems (%d already exist)\n", poemsAdded, skipped)
+		return
+	}
+	fmt.Fprintf(out, "Added %d new poems (%d skipped as existing, %d failed)\n", poemsAdded, skipped, failed)
 
 	poemCount, _ := db.CountPoems()
-	fmt.Printf("\nDone! Total poems in database: %d\n", poemCount)
+	fmt.Fprintf(out, "Total poems in database: %d\n", poemCount)
 }
 
+// truncate shortens s to max characters for progress lines. Characters are
+// grapheme clusters, so Old English letters and emoji are never cut apart.
 func truncate(s string, max int) string {
-	if len(s) <= max {
+	if uniseg.GraphemeClusterCount(s) <= max {
 		return s
 	}
-	return s[:max] + "..."
+	g := uniseg.NewGraphemes(s)
+	end := 0
+	for n := 0; n < max && g.Next(); n++ {
+		_, end = g.Positions()
+	}
+	return s[:end] + "..."
 }
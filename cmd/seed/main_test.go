@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+
+	"Beot/db"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"Wyrd oft nereð", 40, "Wyrd oft nereð"},
+		{"Wyrd oft nereð unfǽgne eorl", 14, "Wyrd oft nereð..."},
+		{"Hwæt! We Gardena", 4, "Hwæt..."},
+		{"ǽ🔥ð", 2, "ǽ🔥..."},
+		{"🏳️‍🌈 pride", 1, "🏳️‍🌈..."},
+	}
+
+	for _, tt := range tests {
+		got := truncate(tt.in, tt.max)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("truncate(%q, %d) is not valid UTF-8", tt.in, tt.max)
+		}
+	}
+}
+
+func TestReportCountsArchivedSubjects(t *testing.T) {
+	store, err := db.OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Use(store)
+	t.Cleanup(func() { db.Use(db.MongoStore{}) })
+
+	db.AddSubject("Go", "🐹")
+	old, _ := db.AddSubject("Latin", "📜")
+	db.SetSubjectArchived(old.ID, true)
+
+	r, err := buildReport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Subjects.Count != 2 || len(r.Subjects.Sample) != 2 {
+		t.Fatalf("want both subjects in the report, got %+v", r.Subjects)
+	}
+	if !r.Subjects.Sample[1].Archived {
+		t.Errorf("the archived subject should be marked, got %+v", r.Subjects.Sample)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"Beot/db"
+)
+
+// reportSampleSize is how many entries of each collection the report shows
+const reportSampleSize = 3
+
+// seedReport is what -report json prints once seeding is done, for scripts
+// and CI to check the database holds what they expect
+type seedReport struct {
+	Quotes   collectionReport[quoteSample]   `json:"quotes"`
+	Subjects collectionReport[subjectSample] `json:"subjects"`
+	Poems    collectionReport[poemSample]    `json:"poems"`
+}
+
+type collectionReport[T any] struct {
+	Count  int64 `json:"count"`
+	Sample []T   `json:"sample"`
+}
+
+type quoteSample struct {
+	Text     string   `json:"text"`
+	Source   string   `json:"source,omitempty"`
+	Subjects []string `json:"subjects"` // Empty = general
+}
+
+type subjectSample struct {
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Archived bool   `json:"archived,omitempty"`
+}
+
+type poemSample struct {
+	Source        string   `json:"source"`
+	LineRef       string   `json:"line_ref,omitempty"`
+	ModernEnglish string   `json:"modern_english"`
+	Subjects      []string `json:"subjects"` // Empty = general
+}
+
+// buildReport counts each collection and takes the first few entries of each
+func buildReport() (seedReport, error) {
+	r := seedReport{
+		Quotes:   collectionReport[quoteSample]{Sample: []quoteSample{}},
+		Subjects: collectionReport[subjectSample]{Sample: []subjectSample{}},
+		Poems:    collectionReport[poemSample]{Sample: []poemSample{}},
+	}
+
+	var err error
+	if r.Quotes.Count, err = db.CountQuotes(); err != nil {
+		return r, err
+	}
+	quotes, err := db.GetAllQuotes()
+	if err != nil {
+		return r, err
+	}
+	for _, q := range quotes[:min(len(quotes), reportSampleSize)] {
+		r.Quotes.Sample = append(r.Quotes.Sample, quoteSample{Text: q.Text, Source: q.Source, Subjects: nonNil(q.Subjects)})
+	}
+
+	// Archived subjects are still in the collection, so they count too
+	subjects, err := db.GetSubjects(true)
+	if err != nil {
+		return r, err
+	}
+	r.Subjects.Count = int64(len(subjects))
+	for _, s := range subjects[:min(len(subjects), reportSampleSize)] {
+		r.Subjects.Sample = append(r.Subjects.Sample, subjectSample{Name: s.Name, Icon: s.Icon, Archived: s.Archived})
+	}
+
+	if r.Poems.Count, err = db.CountPoems(); err != nil {
+		return r, err
+	}
+	poems, err := db.GetAllPoems()
+	if err != nil {
+		return r, err
+	}
+	for _, p := range poems[:min(len(poems), reportSampleSize)] {
+		r.Poems.Sample = append(r.Poems.Sample, poemSample{Source: p.Source, LineRef: p.LineRef, ModernEnglish: p.ModernEnglish, Subjects: nonNil(p.Subjects)})
+	}
+	return r, nil
+}
+
+// writeJSONReport prints the report as indented JSON
+func writeJSONReport(w io.Writer) error {
+	r, err := buildReport()
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// nonNil keeps empty subject lists as [] rather than null in the JSON
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// activeSessionID is the key of the single in-progress session record
+const activeSessionID = "current"
+
+// ActiveSession is a snapshot of the focus block in progress, saved
+// periodically so it can be resumed if the app exits unexpectedly
+type ActiveSession struct {
+	ID             string             `bson:"_id"`
+	SubjectID      primitive.ObjectID `bson:"subject_id"`
+	SubjectName    string             `bson:"subject_name"`
+	Duration       int                `bson:"duration"`        // Planned length in minutes
+	ElapsedSeconds int                `bson:"elapsed_seconds"` // Focus time counted so far
+	PausedSeconds  int                `bson:"paused_seconds,omitempty"`
+	Intention      string             `bson:"intention,omitempty"`
+	Tags           []string           `bson:"tags,omitempty"`
+	StartedAt      time.Time          `bson:"started_at"`
+	SavedAt        time.Time          `bson:"saved_at"`
+}
+
+// RemainingSeconds is the focus time left when the snapshot was taken
+func (a ActiveSession) RemainingSeconds() int {
+	remaining := a.Duration*60 - a.ElapsedSeconds
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func ActiveSessionCollection() (*mongo.Collection, error) {
+	return collection("active_session")
+}
+
+// SaveActiveSession records the in-progress session, replacing any previous one
+func (MongoStore) SaveActiveSession(a ActiveSession) error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := ActiveSessionCollection()
+	if err != nil {
+		return err
+	}
+
+	a.ID = activeSessionID
+	a.SavedAt = time.Now()
+	opts := options.Replace().SetUpsert(true)
+	_, err = coll.ReplaceOne(ctx, bson.M{"_id": activeSessionID}, a, opts)
+	return err
+}
+
+// LoadActiveSession returns the saved in-progress session, or nil if there is none
+func (MongoStore) LoadActiveSession() (*ActiveSession, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := ActiveSessionCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	var a ActiveSession
+	err = coll.FindOne(ctx, bson.M{"_id": activeSessionID}).Decode(&a)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// ClearActiveSession removes the in-progress record once a session ends
+func (MongoStore) ClearActiveSession() error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := ActiveSessionCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.DeleteOne(ctx, bson.M{"_id": activeSessionID})
+	return err
+}
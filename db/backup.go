@@ -0,0 +1,167 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BackupVersion is written to every backup so the format can change later
+const BackupVersion = 1
+
+// Backup is a portable snapshot of everything Beot stores except settings.
+// IDs are written as hex strings and are replaced on restore.
+type Backup struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Subjects  []Subject `json:"subjects"`
+	Quotes    []Quote   `json:"quotes"`
+	Poems     []Poem    `json:"poems"`
+	Sessions  []Session `json:"sessions"`
+}
+
+// RestoreCount tallies one collection's restore
+type RestoreCount struct {
+	Added   int
+	Skipped int // Already present, or (for quotes) invalid
+}
+
+// RestoreSummary reports what a restore did
+type RestoreSummary struct {
+	Subjects RestoreCount
+	Quotes   RestoreCount
+	Poems    RestoreCount
+	Sessions RestoreCount
+}
+
+// WriteBackup writes all subjects (archived included), quotes, poems and
+// sessions to w as indented JSON
+func WriteBackup(w io.Writer) error {
+	backup := Backup{Version: BackupVersion, CreatedAt: time.Now()}
+
+	var err error
+	if backup.Subjects, err = GetSubjects(true); err != nil {
+		return fmt.Errorf("reading subjects: %w", err)
+	}
+	if backup.Quotes, err = GetAllQuotes(); err != nil {
+		return fmt.Errorf("reading quotes: %w", err)
+	}
+	if backup.Poems, err = GetAllPoems(); err != nil {
+		return fmt.Errorf("reading poems: %w", err)
+	}
+	if backup.Sessions, err = GetRecentSessions(0); err != nil {
+		return fmt.Errorf("reading sessions: %w", err)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(backup)
+}
+
+// RestoreBackup reads a backup from r and adds whatever is missing. Existing
+// subjects, quotes, poems and sessions are left alone, so restoring twice is
+// harmless. Sessions are re-pointed at the restored subjects' new IDs.
+func RestoreBackup(r io.Reader) (RestoreSummary, error) {
+	var summary RestoreSummary
+
+	var backup Backup
+	if err := json.NewDecoder(r).Decode(&backup); err != nil {
+		return summary, fmt.Errorf("invalid backup: %w", err)
+	}
+	if backup.Version > BackupVersion {
+		return summary, fmt.Errorf("backup version %d is newer than this Beot supports (%d)", backup.Version, BackupVersion)
+	}
+
+	subjectIDs := make(map[primitive.ObjectID]primitive.ObjectID)
+	for _, s := range backup.Subjects {
+		id, added, err := restoreSubject(s)
+		if err != nil {
+			return summary, fmt.Errorf("restoring subject %q: %w", s.Name, err)
+		}
+		subjectIDs[s.ID] = id
+		if added {
+			summary.Subjects.Added++
+		} else {
+			summary.Subjects.Skipped++
+		}
+	}
+
+	for _, q := range backup.Quotes {
+		quote, added, err := AddQuoteIfNotExists(q.Text, q.Source, q.Subjects)
+		var invalid *ValidationError
+		if errors.As(err, &invalid) {
+			summary.Quotes.Skipped++
+			continue
+		}
+		if err != nil {
+			return summary, fmt.Errorf("restoring quotes: %w", err)
+		}
+		if !added {
+			summary.Quotes.Skipped++
+			continue
+		}
+		if q.Favorite {
+			if err := SetQuoteFavorite(quote.ID, true); err != nil {
+				return summary, fmt.Errorf("restoring quotes: %w", err)
+			}
+		}
+		summary.Quotes.Added++
+	}
+
+	for _, p := range backup.Poems {
+		_, added, err := AddPoemIfNotExists(p.OldEnglish, p.ModernEnglish, p.Source, p.LineRef, p.Subjects)
+		if err != nil {
+			return summary, fmt.Errorf("restoring poems: %w", err)
+		}
+		if added {
+			summary.Poems.Added++
+		} else {
+			summary.Poems.Skipped++
+		}
+	}
+
+	for _, s := range backup.Sessions {
+		if id, ok := subjectIDs[s.SubjectID]; ok {
+			s.SubjectID = id
+		}
+		_, added, err := AddSessionIfNotExists(s)
+		if err != nil {
+			return summary, fmt.Errorf("restoring sessions: %w", err)
+		}
+		if added {
+			summary.Sessions.Added++
+		} else {
+			summary.Sessions.Skipped++
+		}
+	}
+
+	return summary, nil
+}
+
+// restoreSubject returns the ID of the subject with s's name, creating it
+// (with its icon, duration and archived flag) if there is none
+func restoreSubject(s Subject) (primitive.ObjectID, bool, error) {
+	existing, err := GetSubjectByName(s.Name)
+	if err == nil {
+		return existing.ID, false, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return primitive.NilObjectID, false, err
+	}
+
+	created, err := AddSubjectWithDuration(s.Name, s.Icon, s.DefaultDuration)
+	if err != nil {
+		return primitive.NilObjectID, false, err
+	}
+	if s.Archived {
+		if err := SetSubjectArchived(created.ID, true); err != nil {
+			return primitive.NilObjectID, false, err
+		}
+	}
+	return created.ID, true, nil
+}
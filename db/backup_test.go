@@ -0,0 +1,77 @@
+package db
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupRoundTrip(t *testing.T) {
+	defer Use(MongoStore{})
+
+	src, err := OpenLocalStore(filepath.Join(t.TempDir(), "src.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+	Use(src)
+
+	subject, _ := AddSubjectWithDuration("Writing", "✒", 45)
+	SetSubjectArchived(subject.ID, true)
+	quote, _ := AddQuoteWithSubjects("Write drunk, edit sober.", "", []string{"Writing"})
+	SetQuoteFavorite(quote.ID, true)
+	AddPoemWithSubjects("Hwæt!", "Listen!", "Beowulf", "line 1", nil)
+	started := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	CreateSessionWithDetails(subject.ID, subject.Name, 45, StatusCompleted, started, SessionDetails{})
+
+	var buf bytes.Buffer
+	if err := WriteBackup(&buf); err != nil {
+		t.Fatalf("WriteBackup: %v", err)
+	}
+	data := buf.Bytes()
+
+	dst, err := OpenLocalStore(filepath.Join(t.TempDir(), "dst.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+	Use(dst)
+
+	summary, err := RestoreBackup(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("RestoreBackup: %v", err)
+	}
+	want := RestoreSummary{
+		Subjects: RestoreCount{Added: 1},
+		Quotes:   RestoreCount{Added: 1},
+		Poems:    RestoreCount{Added: 1},
+		Sessions: RestoreCount{Added: 1},
+	}
+	if summary != want {
+		t.Errorf("first restore = %+v, want %+v", summary, want)
+	}
+
+	restored, err := GetSubjectByName("Writing")
+	if err != nil {
+		t.Fatalf("restored subject: %v", err)
+	}
+	if restored.ID == subject.ID || !restored.Archived || restored.DefaultDuration != 45 {
+		t.Errorf("restored subject = %+v; want a new ID, archived, 45m", restored)
+	}
+	sessions, _ := GetRecentSessions(0)
+	if len(sessions) != 1 || sessions[0].SubjectID != restored.ID || !sessions[0].StartedAt.Equal(started) {
+		t.Errorf("restored sessions = %+v; want one pointing at the new subject", sessions)
+	}
+	quotes, _ := GetAllQuotes()
+	if len(quotes) != 1 || !quotes[0].Favorite {
+		t.Errorf("restored quotes = %+v; want the favorite kept", quotes)
+	}
+
+	// Restoring again adds nothing
+	summary, err = RestoreBackup(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("second RestoreBackup: %v", err)
+	}
+	if summary.Subjects.Added+summary.Quotes.Added+summary.Poems.Added+summary.Sessions.Added != 0 {
+		t.Errorf("second restore added data: %+v", summary)
+	}
+}
@@ -0,0 +1,54 @@
+package db
+
+// Badge is a milestone earned from session history
+type Badge struct {
+	Name        string
+	Icon        string
+	Description string
+}
+
+// badgeRule pairs a badge with the stats threshold that earns it
+type badgeRule struct {
+	badge  Badge
+	earned func(s SessionStats) bool
+}
+
+// badgeRules lists every badge in display order. Add new milestones here.
+var badgeRules = []badgeRule{
+	{
+		Badge{"First Vow", "🗡", "Completed your first session"},
+		func(s SessionStats) bool { return s.CompletedSessions >= 1 },
+	},
+	{
+		Badge{"7-day streak", "🔥", "Focused seven days in a row"},
+		func(s SessionStats) bool { return s.LongestStreak >= 7 },
+	},
+	{
+		Badge{"100 sessions", "🛡", "Completed one hundred sessions"},
+		func(s SessionStats) bool { return s.CompletedSessions >= 100 },
+	},
+	{
+		Badge{"10 hours focused", "⏳", "Spent ten hours in completed sessions"},
+		func(s SessionStats) bool { return s.TotalMinutes >= 10*60 },
+	},
+}
+
+// BadgesFor returns the badges earned by the given stats, in display order
+func BadgesFor(stats SessionStats) []Badge {
+	var earned []Badge
+	for _, rule := range badgeRules {
+		if rule.earned(stats) {
+			earned = append(earned, rule.badge)
+		}
+	}
+	return earned
+}
+
+// GetEarnedBadges derives the earned badges from the current session stats
+func GetEarnedBadges() ([]Badge, error) {
+	stats, err := GetSessionStats()
+	if err != nil {
+		return nil, err
+	}
+	return BadgesFor(*stats), nil
+}
@@ -0,0 +1,31 @@
+package db
+
+import "testing"
+
+func TestBadgesFor(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats SessionStats
+		want  []string
+	}{
+		{"none", SessionStats{}, nil},
+		{"first vow", SessionStats{CompletedSessions: 1, TotalMinutes: 25}, []string{"First Vow"}},
+		{"streak", SessionStats{CompletedSessions: 7, LongestStreak: 7}, []string{"First Vow", "7-day streak"}},
+		{"all", SessionStats{CompletedSessions: 100, LongestStreak: 30, TotalMinutes: 2500},
+			[]string{"First Vow", "7-day streak", "100 sessions", "10 hours focused"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BadgesFor(tt.stats)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d badges, want %d", len(got), len(tt.want))
+			}
+			for i, b := range got {
+				if b.Name != tt.want[i] {
+					t.Errorf("badge %d = %q, want %q", i, b.Name, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+// FileConfig is the layout of ~/.config/beot/config.toml. Every key is
+// optional; environment variables (including .env) take precedence. Theme,
+// alert and week start are only defaults: a choice saved in Settings wins.
+type FileConfig struct {
+	MongoDBURI     string `toml:"mongodb_uri"`     // BEOT_MONGODB_URI
+	Database       string `toml:"database"`        // BEOT_DATABASE
+	DefaultMinutes int    `toml:"default_minutes"` // BEOT_DEFAULT_MINUTES
+	Theme          string `toml:"theme"`           // Beneath BEOT_THEME
+	Alert          string `toml:"alert"`           // Beneath BEOT_ALERT and the saved setting
+	WeekStart      string `toml:"week_start"`      // Beneath BEOT_WEEK_START and the saved setting
+	Lang           string `toml:"lang"`            // BEOT_LANG
+
+	// Profiles are named connections chosen with --profile or BEOT_PROFILE
+	Profiles map[string]Profile `toml:"profiles"`
+
+	// Keys remaps keys by view, then action, e.g.
+	//
+	//	[keys.timer]
+	//	pause = ["p"]
+	Keys map[string]map[string][]string `toml:"keys"`
+}
+
+// Profile is a named MongoDB connection from the config file, e.g.
+//
+//	[profiles.dev]
+//	mongodb_uri = "mongodb://localhost:27017"
+//	database = "beot_dev"
+type Profile struct {
+	MongoDBURI string `toml:"mongodb_uri"`
+	Database   string `toml:"database"` // Defaults to DefaultDatabase
+}
+
+// fileConfig is the config file as loaded at startup
+var fileConfig FileConfig
+
+// ConfigErr records a config file that exists but could not be read.
+// Open reports it so a typo is not silently ignored.
+var ConfigErr error
+
+// ConfigPath returns BEOT_CONFIG, or beot/config.toml in the user config directory
+func ConfigPath() (string, error) {
+	if path := os.Getenv("BEOT_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "beot", "config.toml"), nil
+}
+
+// loadConfigFile reads the config file at path. A missing file is not an error.
+func loadConfigFile(path string) (FileConfig, error) {
+	var cfg FileConfig
+	_, err := toml.DecodeFile(path, &cfg)
+	if errors.Is(err, os.ErrNotExist) {
+		return FileConfig{}, nil
+	}
+	return cfg, err
+}
+
+// applyConfig exports config values as environment variables that are not
+// already set, so the rest of the app reads one source. Values that are also
+// settings are not exported: the variables lock those settings, so they are
+// read through configDefault instead.
+func applyConfig(cfg FileConfig) {
+	values := map[string]string{
+		"BEOT_MONGODB_URI": cfg.MongoDBURI,
+		"BEOT_DATABASE":    cfg.Database,
+		"BEOT_LANG":        cfg.Lang,
+	}
+	if cfg.DefaultMinutes > 0 {
+		values["BEOT_DEFAULT_MINUTES"] = strconv.Itoa(cfg.DefaultMinutes)
+	}
+	for key, value := range values {
+		if _, set := os.LookupEnv(key); !set && value != "" {
+			os.Setenv(key, value)
+		}
+	}
+}
+
+// loadConfig applies the config file beneath the environment
+func loadConfig() {
+	path, err := ConfigPath()
+	if err != nil {
+		return
+	}
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		ConfigErr = err
+		return
+	}
+	fileConfig = cfg
+	applyConfig(cfg)
+}
+
+// configDefault returns the config file value for a setting that has never
+// been saved, or ""
+func configDefault(key string) string {
+	switch key {
+	case SettingAlert:
+		return fileConfig.Alert
+	case SettingWeekStart:
+		return fileConfig.WeekStart
+	}
+	return ""
+}
+
+// ConfigTheme returns the theme named in the config file, or ""
+func ConfigTheme() string {
+	return fileConfig.Theme
+}
+
+// KeyOverrides returns the [keys] tables from the config file
+func KeyOverrides() map[string]map[string][]string {
+	return fileConfig.Keys
+}
+
+// ProfileName returns the connection profile chosen with BEOT_PROFILE, or "" for none
+func ProfileName() string {
+	return os.Getenv("BEOT_PROFILE")
+}
+
+// resolveProfile returns the URI and database to connect to. With no
+// profile chosen they come from BEOT_MONGODB_URI and BEOT_DATABASE.
+func resolveProfile(profiles map[string]Profile) (uri, database string, err error) {
+	name := ProfileName()
+	if name == "" {
+		uri, err = getMongoURI()
+		return uri, databaseName(), err
+	}
+
+	p, ok := profiles[name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown profile %q: add a [profiles.%s] section to the config file", name, name)
+	}
+	if p.MongoDBURI == "" {
+		return "", "", fmt.Errorf("profile %q has no mongodb_uri", name)
+	}
+	if err := validateMongoURI(p.MongoDBURI, fmt.Sprintf("profile %q", name)); err != nil {
+		return "", "", err
+	}
+	if p.Database == "" {
+		p.Database = DefaultDatabase
+	}
+	return p.MongoDBURI, p.Database, nil
+}
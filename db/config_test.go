@@ -0,0 +1,155 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadConfigFileMissing(t *testing.T) {
+	cfg, err := loadConfigFile(filepath.Join(t.TempDir(), "config.toml"))
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, FileConfig{}) {
+		t.Errorf("got %+v, want zero config", cfg)
+	}
+}
+
+func TestApplyConfigEnvWins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "database = \"study\"\ndefault_minutes = 45\ntheme = \"dark\"\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("BEOT_THEME", "light")
+	t.Setenv("BEOT_DATABASE", "")
+	os.Unsetenv("BEOT_DATABASE")
+	t.Setenv("BEOT_DEFAULT_MINUTES", "")
+	os.Unsetenv("BEOT_DEFAULT_MINUTES")
+	applyConfig(cfg)
+
+	if got := os.Getenv("BEOT_THEME"); got != "light" {
+		t.Errorf("BEOT_THEME = %q, env should win over config", got)
+	}
+	if got := databaseName(); got != "study" {
+		t.Errorf("databaseName() = %q, want study", got)
+	}
+	if got := DefaultDurations().Work; got != 45 {
+		t.Errorf("DefaultDurations().Work = %d, want 45", got)
+	}
+}
+
+func TestConfigSettingsAreDefaults(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Use(store)
+	defer Use(MongoStore{})
+
+	saved := fileConfig
+	t.Cleanup(func() { fileConfig = saved })
+	fileConfig = FileConfig{Alert: "triple", WeekStart: "sunday"}
+	for _, key := range []string{"BEOT_ALERT", "BEOT_WEEK_START"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	applyConfig(fileConfig)
+
+	// Exported, they would lock the settings as if set in the environment
+	if _, set := os.LookupEnv("BEOT_ALERT"); set {
+		t.Error("the config alert should not be exported as BEOT_ALERT")
+	}
+	if _, locked := WeekStartOverride(); locked {
+		t.Error("the config week start should not lock the setting")
+	}
+
+	if day, _ := GetWeekStart(); day != time.Sunday {
+		t.Errorf("unsaved week start = %v, want the config's Sunday", day)
+	}
+	if err := SetWeekStart(time.Monday); err != nil {
+		t.Fatal(err)
+	}
+	if day, _ := GetWeekStart(); day != time.Monday {
+		t.Errorf("saved week start = %v, the saved Monday should win", day)
+	}
+	if value, _ := GetSetting(SettingAlert); value != "triple" {
+		t.Errorf("unsaved alert = %q, want the config's triple", value)
+	}
+}
+
+func TestLoadConfigFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("default_minutes = \"lots\""), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfigFile(path); err == nil {
+		t.Error("expected an error for a mistyped value")
+	}
+}
+
+func TestResolveProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "[profiles.dev]\nmongodb_uri = \"mongodb://localhost:27017\"\ndatabase = \"beot_dev\"\n\n" +
+		"[profiles.remote]\nmongodb_uri = \"mongodb+srv://example.net\"\n\n[profiles.broken]\ndatabase = \"x\"\n\n" +
+		"[profiles.typo]\nmongodb_uri = \"mongdb://localhost\"\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BEOT_MONGODB_URI", "mongodb://env:27017")
+	t.Setenv("BEOT_DATABASE", "envdb")
+
+	tests := []struct {
+		profile, uri, database string
+		wantErr                bool
+	}{
+		{"", "mongodb://env:27017", "envdb", false},
+		{"dev", "mongodb://localhost:27017", "beot_dev", false},
+		{"remote", "mongodb+srv://example.net", DefaultDatabase, false},
+		{"broken", "", "", true},
+		{"typo", "", "", true},
+		{"missing", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Setenv("BEOT_PROFILE", tt.profile)
+		uri, database, err := resolveProfile(cfg.Profiles)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("profile %q: err = %v, want error %v", tt.profile, err, tt.wantErr)
+			continue
+		}
+		if uri != tt.uri || database != tt.database {
+			t.Errorf("profile %q: got %s/%s, want %s/%s", tt.profile, uri, database, tt.uri, tt.database)
+		}
+	}
+}
+
+func TestLoadConfigFileKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "[keys.timer]\npause = [\"p\"]\nextend = [\"+\", \"=\"]\n\n[keys.menu]\nquit = [\"x\"]\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string][]string{
+		"timer": {"pause": {"p"}, "extend": {"+", "="}},
+		"menu":  {"quit": {"x"}},
+	}
+	if !reflect.DeepEqual(cfg.Keys, want) {
+		t.Errorf("keys = %v, want %v", cfg.Keys, want)
+	}
+}
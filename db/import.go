@@ -0,0 +1,99 @@
+package db
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// QuoteRecord is a quote as read from an import file
+type QuoteRecord struct {
+	Text     string   `json:"text"`
+	Source   string   `json:"source"`
+	Subjects []string `json:"subjects"`
+}
+
+// ImportQuotes reads quotes from r in the given format ("json" or "csv") and
+// adds each one that doesn't already exist.
+//
+// JSON input is an array of {text, source, subjects} objects. CSV input has
+// columns text, source, subjects (semicolon separated) with an optional header row.
+func ImportQuotes(r io.Reader, format string) (added int, skipped int, err error) {
+	var records []QuoteRecord
+	switch strings.ToLower(format) {
+	case "json":
+		records, err = parseQuotesJSON(r)
+	case "csv":
+		records, err = parseQuotesCSV(r)
+	default:
+		return 0, 0, fmt.Errorf("unsupported import format %q (use json or csv)", format)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, rec := range records {
+		_, created, err := AddQuoteIfNotExists(rec.Text, rec.Source, rec.Subjects)
+		var invalid *ValidationError
+		if errors.As(err, &invalid) {
+			// Empty or over-long rows are skipped rather than aborting the import
+			skipped++
+			continue
+		}
+		if err != nil {
+			return added, skipped, err
+		}
+		if created {
+			added++
+		} else {
+			skipped++
+		}
+	}
+	return added, skipped, nil
+}
+
+func parseQuotesJSON(r io.Reader) ([]QuoteRecord, error) {
+	var records []QuoteRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return records, nil
+}
+
+func parseQuotesCSV(r io.Reader) ([]QuoteRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // source and subjects columns are optional
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	var records []QuoteRecord
+	for i, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		// Skip a header row
+		if i == 0 && strings.EqualFold(strings.TrimSpace(row[0]), "text") {
+			continue
+		}
+
+		rec := QuoteRecord{Text: row[0]}
+		if len(row) > 1 {
+			rec.Source = row[1]
+		}
+		if len(row) > 2 {
+			for _, s := range strings.Split(row[2], ";") {
+				if s = strings.TrimSpace(s); s != "" {
+					rec.Subjects = append(rec.Subjects, s)
+				}
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
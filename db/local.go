@@ -0,0 +1,818 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// localData is the on-disk layout of the local JSON store
+type localData struct {
+	Quotes   []Quote           `json:"quotes"`
+	Poems    []Poem            `json:"poems"`
+	Subjects []Subject         `json:"subjects"`
+	Sessions []Session         `json:"sessions"`
+	Settings map[string]string `json:"settings"`
+	// Active is the in-progress session snapshot, if any
+	Active *ActiveSession `json:"active_session,omitempty"`
+}
+
+// clone copies d so changes to one leave the other alone
+func (d localData) clone() localData {
+	d.Quotes = slices.Clone(d.Quotes)
+	d.Poems = slices.Clone(d.Poems)
+	d.Subjects = slices.Clone(d.Subjects)
+	d.Sessions = slices.Clone(d.Sessions)
+	d.Settings = maps.Clone(d.Settings)
+	if d.Active != nil {
+		active := *d.Active
+		d.Active = &active
+	}
+	return d
+}
+
+// LocalStore keeps all data in a single JSON file so Beot can run without MongoDB
+type LocalStore struct {
+	mu    sync.Mutex
+	path  string
+	data  localData
+	saved localData // What the file holds, restored when a save fails
+}
+
+// DefaultLocalStorePath returns ~/.config/beot/data.json (or the OS equivalent)
+func DefaultLocalStorePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "beot", "data.json"), nil
+}
+
+// OpenLocalStore loads the store at path, creating it if missing.
+// An empty path uses BEOT_LOCAL_PATH or DefaultLocalStorePath.
+func OpenLocalStore(path string) (*LocalStore, error) {
+	if path == "" {
+		path = os.Getenv("BEOT_LOCAL_PATH")
+	}
+	if path == "" {
+		p, err := DefaultLocalStorePath()
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+
+	s := &LocalStore{path: path}
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &s.data); err != nil {
+			return nil, err
+		}
+	}
+	if s.data.Settings == nil {
+		s.data.Settings = make(map[string]string)
+	}
+	// Quotes saved before the normalized field existed; written on the next save
+	for i := range s.data.Quotes {
+		if s.data.Quotes[i].Normalized == "" {
+			s.data.Quotes[i].Normalized = QuoteMatchKey(s.data.Quotes[i].Text)
+		}
+	}
+	s.saved = s.data.clone()
+	return s, nil
+}
+
+// save writes the store atomically via a temp file. Mutators change memory
+// first and then save; if the write fails, memory goes back to what the file
+// holds so the two never disagree. Callers must hold mu.
+func (s *LocalStore) save() error {
+	if err := s.write(); err != nil {
+		s.data = s.saved.clone()
+		return err
+	}
+	s.saved = s.data.clone()
+	return nil
+}
+
+func (s *LocalStore) write() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Quotes
+
+func (s *LocalStore) GetAllQuotes() ([]Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Quote(nil), s.data.Quotes...), nil
+}
+
+func (s *LocalStore) GetQuotesForSubject(subjectName string) ([]Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var quotes []Quote
+	for _, q := range s.data.Quotes {
+		if matchesSubject(q.Subjects, subjectName) {
+			quotes = append(quotes, q)
+		}
+	}
+	return quotes, nil
+}
+
+func (s *LocalStore) GetRandomQuoteMatching(f QuoteFilter) (*Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matches []Quote
+	for _, q := range s.data.Quotes {
+		if f.Matches(q) {
+			matches = append(matches, q)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	q := matches[rand.Intn(len(matches))]
+	return &q, nil
+}
+
+func (s *LocalStore) AddQuoteWithSubjects(text, source string, subjects []string) (*Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findQuoteByKey(QuoteMatchKey(text)) != nil {
+		return nil, ErrDuplicateQuote
+	}
+	quote := Quote{
+		ID:         primitive.NewObjectID(),
+		Text:       text,
+		Source:     source,
+		Subjects:   subjects,
+		CreatedAt:  time.Now(),
+		Normalized: QuoteMatchKey(text),
+	}
+	s.data.Quotes = append(s.data.Quotes, quote)
+	return &quote, s.save()
+}
+
+// findQuoteByKey returns the quote with the given QuoteMatchKey, or nil.
+// Callers must hold mu.
+func (s *LocalStore) findQuoteByKey(key string) *Quote {
+	for _, q := range s.data.Quotes {
+		if key != "" && q.Normalized == key {
+			return &q
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) AddQuoteIfNotExists(text, source string, subjects []string) (*Quote, bool, error) {
+	s.mu.Lock()
+	existing := s.findQuoteByKey(QuoteMatchKey(text))
+	s.mu.Unlock()
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	quote, err := s.AddQuoteWithSubjects(text, source, subjects)
+	return quote, err == nil, err
+}
+
+func (s *LocalStore) SetQuoteFavorite(id primitive.ObjectID, favorite bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.Quotes {
+		if s.data.Quotes[i].ID == id {
+			s.data.Quotes[i].Favorite = favorite
+			return s.save()
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) DeleteQuote(id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, q := range s.data.Quotes {
+		if q.ID == id {
+			s.data.Quotes = append(s.data.Quotes[:i], s.data.Quotes[i+1:]...)
+			return s.save()
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) DeleteQuotesBySubject(subjectName string) (int64, error) {
+	if subjectName == "" {
+		return 0, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept []Quote
+	for _, q := range s.data.Quotes {
+		if !containsString(q.Subjects, subjectName) {
+			kept = append(kept, q)
+		}
+	}
+	deleted := int64(len(s.data.Quotes) - len(kept))
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	s.data.Quotes = kept
+	if err := s.save(); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+func (s *LocalStore) CountQuotes() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(len(s.data.Quotes)), nil
+}
+
+// Poems
+
+func (s *LocalStore) GetAllPoems() ([]Poem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Poem(nil), s.data.Poems...), nil
+}
+
+func (s *LocalStore) GetPoemsForSubject(subjectName string) ([]Poem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var poems []Poem
+	for _, p := range s.data.Poems {
+		if matchesSubject(p.Subjects, subjectName) {
+			poems = append(poems, p)
+		}
+	}
+	return poems, nil
+}
+
+func (s *LocalStore) GetRandomPoemForSubject(subjectName string) (*Poem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matches []Poem
+	for _, p := range s.data.Poems {
+		if matchesSubject(p.Subjects, subjectName) {
+			matches = append(matches, p)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	p := matches[rand.Intn(len(matches))]
+	return &p, nil
+}
+
+func (s *LocalStore) AddPoemWithSubjects(oldEnglish, modernEnglish, source, lineRef string, subjects []string) (*Poem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	poem := Poem{
+		ID:            primitive.NewObjectID(),
+		OldEnglish:    oldEnglish,
+		ModernEnglish: modernEnglish,
+		Source:        source,
+		LineRef:       lineRef,
+		Subjects:      subjects,
+		CreatedAt:     time.Now(),
+	}
+	s.data.Poems = append(s.data.Poems, poem)
+	return &poem, s.save()
+}
+
+func (s *LocalStore) AddPoemIfNotExists(oldEnglish, modernEnglish, source, lineRef string, subjects []string) (*Poem, bool, error) {
+	s.mu.Lock()
+	for _, p := range s.data.Poems {
+		if p.Source == source && p.LineRef == lineRef {
+			s.mu.Unlock()
+			return &p, false, nil
+		}
+	}
+	s.mu.Unlock()
+
+	poem, err := s.AddPoemWithSubjects(oldEnglish, modernEnglish, source, lineRef, subjects)
+	return poem, err == nil, err
+}
+
+func (s *LocalStore) UpdatePoem(id primitive.ObjectID, oldEnglish, modernEnglish, source, lineRef string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.Poems {
+		if p := &s.data.Poems[i]; p.ID == id {
+			p.OldEnglish, p.ModernEnglish, p.Source, p.LineRef = oldEnglish, modernEnglish, source, lineRef
+			return s.save()
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (s *LocalStore) DeletePoem(id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.data.Poems {
+		if p.ID == id {
+			s.data.Poems = append(s.data.Poems[:i], s.data.Poems[i+1:]...)
+			return s.save()
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) CountPoems() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(len(s.data.Poems)), nil
+}
+
+// Subjects
+
+func (s *LocalStore) GetSubjects(includeArchived bool) ([]Subject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var subjects []Subject
+	for _, sub := range s.data.Subjects {
+		if includeArchived || !sub.Archived {
+			subjects = append(subjects, sub)
+		}
+	}
+	sortSubjects(subjects)
+	return subjects, nil
+}
+
+func (s *LocalStore) SetSubjectArchived(id primitive.ObjectID, archived bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.Subjects {
+		if s.data.Subjects[i].ID == id {
+			s.data.Subjects[i].Archived = archived
+			return s.save()
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (s *LocalStore) SetSubjectStrictQuotes(id primitive.ObjectID, strict bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.Subjects {
+		if s.data.Subjects[i].ID == id {
+			s.data.Subjects[i].StrictQuotes = strict
+			return s.save()
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (s *LocalStore) SetSubjectOrder(ids []primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, id := range ids {
+		for j := range s.data.Subjects {
+			if s.data.Subjects[j].ID == id {
+				s.data.Subjects[j].Order = i + 1
+			}
+		}
+	}
+	return s.save()
+}
+
+func (s *LocalStore) GetSubjectByID(id primitive.ObjectID) (*Subject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.data.Subjects {
+		if sub.ID == id {
+			return &sub, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s *LocalStore) GetSubjectByName(name string) (*Subject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.data.Subjects {
+		if sub.Name == name {
+			return &sub, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (s *LocalStore) AddSubjectWithDuration(name, icon string, duration int) (*Subject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subject := Subject{
+		ID:              primitive.NewObjectID(),
+		Name:            name,
+		Icon:            icon,
+		DefaultDuration: duration,
+		CreatedAt:       time.Now(),
+	}
+	s.data.Subjects = append(s.data.Subjects, subject)
+	return &subject, s.save()
+}
+
+func (s *LocalStore) AddSubjectIfNotExists(name, icon string) (*Subject, bool, error) {
+	if existing, err := s.GetSubjectByName(name); err == nil {
+		return existing, false, nil
+	}
+	subject, err := s.AddSubjectWithDuration(name, icon, 0)
+	return subject, err == nil, err
+}
+
+func (s *LocalStore) DeleteSubject(id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, sub := range s.data.Subjects {
+		if sub.ID == id {
+			s.data.Subjects = append(s.data.Subjects[:i], s.data.Subjects[i+1:]...)
+			return s.save()
+		}
+	}
+	return nil
+}
+
+// Sessions
+
+func (s *LocalStore) CreateSessionWithDetails(subjectID primitive.ObjectID, subjectName string, duration int, status SessionStatus, startedAt time.Time, details SessionDetails) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session := newSession(subjectID, subjectName, duration, status, startedAt, details)
+	session.ID = primitive.NewObjectID()
+	s.data.Sessions = append(s.data.Sessions, session)
+	return &session, s.save()
+}
+
+func (s *LocalStore) AddSessionIfNotExists(session Session) (*Session, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.data.Sessions {
+		if existing.SubjectName == session.SubjectName && existing.StartedAt.Equal(session.StartedAt) {
+			return &existing, false, nil
+		}
+	}
+
+	session.ID = primitive.NewObjectID()
+	s.data.Sessions = append(s.data.Sessions, session)
+	return &session, true, s.save()
+}
+
+func (s *LocalStore) SetSessionReflection(id primitive.ObjectID, rating int, note string) error {
+	if rating < 0 || rating > MaxRating {
+		return &ValidationError{Field: "rating", Message: fmt.Sprintf("must be between 1 and %d", MaxRating)}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.data.Sessions {
+		if s.data.Sessions[i].ID == id {
+			s.data.Sessions[i].Rating = rating
+			s.data.Sessions[i].Note = note
+			return s.save()
+		}
+	}
+	return mongo.ErrNoDocuments
+}
+
+func (s *LocalStore) DeleteSession(id primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, sess := range s.data.Sessions {
+		if sess.ID == id {
+			s.data.Sessions = append(s.data.Sessions[:i], s.data.Sessions[i+1:]...)
+			return s.save()
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) GetRecentSessions(limit int) ([]Session, error) {
+	s.mu.Lock()
+	sessions := append([]Session(nil), s.data.Sessions...)
+	s.mu.Unlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CompletedAt.After(sessions[j].CompletedAt)
+	})
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions, nil
+}
+
+func (s *LocalStore) GetLongestSession() (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var longest *Session
+	for _, sess := range s.data.Sessions {
+		if sess.Status != StatusCompleted || sess.Segment {
+			continue
+		}
+		if longest == nil || sess.Duration > longest.Duration ||
+			(sess.Duration == longest.Duration && sess.CompletedAt.Before(longest.CompletedAt)) {
+			found := sess
+			longest = &found
+		}
+	}
+	return longest, nil
+}
+
+func (s *LocalStore) GetAverageRating(subjectName string) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total, rated := 0, 0
+	for _, sess := range s.data.Sessions {
+		if sess.SubjectName != subjectName || sess.Rating < 1 {
+			continue
+		}
+		total += sess.Rating
+		rated++
+	}
+	if rated == 0 {
+		return 0, nil
+	}
+	return float64(total) / float64(rated), nil
+}
+
+func (s *LocalStore) GetSessionsByHour() (map[int]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var completed []Session
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted {
+			completed = append(completed, sess)
+		}
+	}
+	return sessionsByHour(completed), nil
+}
+
+func (s *LocalStore) GetMinutesByWeekday() ([7]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var completed []Session
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted {
+			completed = append(completed, sess)
+		}
+	}
+	return minutesByWeekday(completed), nil
+}
+
+func (s *LocalStore) GetActivityForMonth(year int, month time.Month) (map[int]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var completed []Session
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted {
+			completed = append(completed, sess)
+		}
+	}
+	return minutesByDayOfMonth(completed, year, month), nil
+}
+
+func (s *LocalStore) GetMinutesByDay(start time.Time, days int) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var completed []Session
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted {
+			completed = append(completed, sess)
+		}
+	}
+	return minutesByDay(completed, start, days), nil
+}
+
+func (s *LocalStore) CountCompletedSince(since time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted && !sess.Segment && !sess.StartedAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *LocalStore) GetMinutesSince(since time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	minutes := 0
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted && !sess.StartedAt.Before(since) {
+			minutes += sess.Duration
+		}
+	}
+	return minutes, nil
+}
+
+func (s *LocalStore) GetSessionStats() (*SessionStats, error) {
+	return s.GetSessionStatsRange(time.Time{})
+}
+
+// GetSessionStatsRange matches MongoStore: counts cover sessions finished
+// at or after since, streaks are all-time
+func (s *LocalStore) GetSessionStatsRange(since time.Time) (*SessionStats, error) {
+	freezes := loadStreakFreezes(s.GetSetting) // Takes the lock itself
+	countAbandoned := loadStreaksCountAbandoned(s.GetSetting)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := &SessionStats{}
+	var streakDays []Session
+	for _, sess := range s.data.Sessions {
+		if countsTowardStreak(sess.Status, countAbandoned) {
+			streakDays = append(streakDays, sess)
+		}
+		if sess.CompletedAt.Before(since) {
+			continue
+		}
+		stats.PausedSeconds += sess.PausedSeconds
+		if sess.Status == StatusCompleted {
+			stats.TotalMinutes += sess.Duration
+		}
+		if sess.Segment {
+			continue
+		}
+		stats.TotalSessions++
+		if sess.Status == StatusCompleted {
+			stats.CompletedSessions++
+		}
+	}
+	stats.AbandonedSessions = stats.TotalSessions - stats.CompletedSessions
+	stats.CurrentStreak, stats.LongestStreak = CalculateStreaksWithFreezes(sessionDays(streakDays), time.Now(), freezes)
+	return stats, nil
+}
+
+func (s *LocalStore) GetSessionsBySubject() (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make(map[string]int)
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted && !sess.Segment {
+			results[sess.SubjectName]++
+		}
+	}
+	return results, nil
+}
+
+func (s *LocalStore) GetMinutesBySubject() (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make(map[string]int)
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted {
+			results[sess.SubjectName] += sess.Duration
+		}
+	}
+	return results, nil
+}
+
+func (s *LocalStore) GetMinutesByTag() (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make(map[string]int)
+	for _, sess := range s.data.Sessions {
+		if sess.Status == StatusCompleted {
+			for _, tag := range sess.Tags {
+				results[tag] += sess.Duration
+			}
+		}
+	}
+	return results, nil
+}
+
+// Active session recovery
+
+func (s *LocalStore) SaveActiveSession(a ActiveSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a.ID = activeSessionID
+	a.SavedAt = time.Now()
+	s.data.Active = &a
+	return s.save()
+}
+
+func (s *LocalStore) LoadActiveSession() (*ActiveSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data.Active == nil {
+		return nil, nil
+	}
+	a := *s.data.Active
+	return &a, nil
+}
+
+func (s *LocalStore) ClearActiveSession() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data.Active == nil {
+		return nil
+	}
+	s.data.Active = nil
+	return s.save()
+}
+
+// Settings
+
+func (s *LocalStore) GetSetting(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.Settings[key], nil
+}
+
+func (s *LocalStore) SetSetting(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Settings[key] = value
+	return s.save()
+}
+
+func (s *LocalStore) ClearContent() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Quotes = nil
+	s.data.Subjects = nil
+	s.data.Poems = nil
+	return s.save()
+}
+
+// Ping always succeeds; a local file has no connection to lose
+func (s *LocalStore) Ping() error {
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
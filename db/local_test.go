@@ -0,0 +1,627 @@
+package db
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLocalStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+
+	store, err := OpenLocalStore(path)
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	subject, added, err := store.AddSubjectIfNotExists("GoLang", "🔷")
+	if err != nil || !added {
+		t.Fatalf("AddSubjectIfNotExists = %v, %v", added, err)
+	}
+	if _, added, _ := store.AddSubjectIfNotExists("GoLang", "🔷"); added {
+		t.Error("duplicate subject was added")
+	}
+	if _, err := store.CreateSessionWithDetails(subject.ID, subject.Name, 25, StatusCompleted, time.Now(), SessionDetails{}); err != nil {
+		t.Fatalf("CreateSessionWithDetails: %v", err)
+	}
+	if err := store.SetSetting(SettingDisplayMode, "poems"); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+
+	// Reopen from disk
+	store, err = OpenLocalStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+
+	got, err := store.GetSubjectByName("GoLang")
+	if err != nil || got.ID != subject.ID {
+		t.Errorf("GetSubjectByName = %v, %v; want ID %s", got, err, subject.ID.Hex())
+	}
+
+	stats, _ := store.GetSessionStats()
+	if stats.CompletedSessions != 1 || stats.TotalMinutes != 25 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	if mode, _ := store.GetSetting(SettingDisplayMode); mode != "poems" {
+		t.Errorf("GetSetting = %q, want poems", mode)
+	}
+
+	if _, err := store.GetSubjectByID(primitive.NewObjectID()); err == nil {
+		t.Error("GetSubjectByID for unknown ID returned no error")
+	}
+}
+
+func TestLocalStoreActiveSession(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	if a, err := store.LoadActiveSession(); err != nil || a != nil {
+		t.Fatalf("LoadActiveSession on empty store = %v, %v; want nil, nil", a, err)
+	}
+
+	want := ActiveSession{SubjectName: "GoLang", Duration: 25, ElapsedSeconds: 600, StartedAt: time.Now()}
+	if err := store.SaveActiveSession(want); err != nil {
+		t.Fatalf("SaveActiveSession: %v", err)
+	}
+	got, err := store.LoadActiveSession()
+	if err != nil || got == nil {
+		t.Fatalf("LoadActiveSession = %v, %v", got, err)
+	}
+	if got.SubjectName != "GoLang" || got.RemainingSeconds() != 15*60 {
+		t.Errorf("loaded %+v, want GoLang with 900s remaining", got)
+	}
+
+	if err := store.ClearActiveSession(); err != nil {
+		t.Fatalf("ClearActiveSession: %v", err)
+	}
+	if a, _ := store.LoadActiveSession(); a != nil {
+		t.Errorf("active session still present after clear: %+v", a)
+	}
+}
+
+func TestReorderSubject(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+	previous := active
+	Use(store)
+	defer Use(previous)
+
+	var ids []primitive.ObjectID
+	for _, name := range []string{"GoLang", "React", "Writing"} {
+		subject, err := store.AddSubjectWithDuration(name, "📚", 0)
+		if err != nil {
+			t.Fatalf("AddSubjectWithDuration: %v", err)
+		}
+		ids = append(ids, subject.ID)
+	}
+
+	if err := ReorderSubject(ids[2], 0); err != nil {
+		t.Fatalf("ReorderSubject: %v", err)
+	}
+	// A subject added afterwards has no position yet and goes last
+	if _, err := store.AddSubjectWithDuration("Reading", "📖", 0); err != nil {
+		t.Fatalf("AddSubjectWithDuration: %v", err)
+	}
+
+	subjects, err := GetAllSubjects()
+	if err != nil {
+		t.Fatalf("GetAllSubjects: %v", err)
+	}
+	var got []string
+	for _, s := range subjects {
+		got = append(got, s.Name)
+	}
+	want := []string{"Writing", "GoLang", "React", "Reading"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestLocalStoreLongestSession(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	if longest, err := store.GetLongestSession(); err != nil || longest != nil {
+		t.Fatalf("empty store: got %v, %v; want nil, nil", longest, err)
+	}
+
+	id := primitive.NewObjectID()
+	store.CreateSessionWithDetails(id, "Go", 50, StatusCompleted, time.Now(), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Writing", 90, StatusAbandoned, time.Now(), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Music", 60, StatusCompleted, time.Now(), SessionDetails{})
+
+	longest, err := store.GetLongestSession()
+	if err != nil {
+		t.Fatalf("GetLongestSession: %v", err)
+	}
+	if longest == nil || longest.SubjectName != "Music" || longest.Duration != 60 {
+		t.Errorf("got %+v, want the 60m Music session (abandoned sessions don't count)", longest)
+	}
+}
+
+func TestLocalStoreHabits(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	id := primitive.NewObjectID()
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, day.Add(9*time.Hour), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, day.Add(9*time.Hour+40*time.Minute), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, day.Add(23*time.Hour), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusAbandoned, day.Add(9*time.Hour), SessionDetails{})
+
+	got, err := store.GetSessionsByHour()
+	if err != nil {
+		t.Fatalf("GetSessionsByHour: %v", err)
+	}
+	want := map[int]int{9: 2, 23: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// 2 March 2026 is a Monday; the abandoned session doesn't count
+	store.CreateSessionWithDetails(id, "Go", 50, StatusCompleted, day.AddDate(0, 0, 6).Add(10*time.Hour), SessionDetails{})
+	byDay, err := store.GetMinutesByWeekday()
+	if err != nil {
+		t.Fatalf("GetMinutesByWeekday: %v", err)
+	}
+	if wantDays := [7]int{75, 0, 0, 0, 0, 0, 50}; byDay != wantDays {
+		t.Errorf("GetMinutesByWeekday = %v, want %v", byDay, wantDays)
+	}
+}
+
+func TestLocalStoreActivityForMonth(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	id := primitive.NewObjectID()
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, march.Add(9*time.Hour), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 50, StatusCompleted, march.Add(23*time.Hour+30*time.Minute), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, march.AddDate(0, 0, 30).Add(12*time.Hour), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusAbandoned, march.AddDate(0, 0, 4), SessionDetails{})
+	// Either side of the month
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, march.Add(-time.Minute), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, march.AddDate(0, 1, 0), SessionDetails{})
+
+	got, err := store.GetActivityForMonth(2026, time.March)
+	if err != nil {
+		t.Fatalf("GetActivityForMonth: %v", err)
+	}
+	if want := map[int]int{1: 75, 31: 25}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetActivityForMonth = %v, want %v", got, want)
+	}
+}
+
+func TestLocalStoreMinutesByDay(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	// A week spanning the end of March
+	id := primitive.NewObjectID()
+	monday := time.Date(2026, 3, 30, 0, 0, 0, 0, time.Local)
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, monday.Add(9*time.Hour), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 50, StatusCompleted, monday.AddDate(0, 0, 2).Add(23*time.Hour), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 30, StatusCompleted, monday.AddDate(0, 0, 6), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusAbandoned, monday.AddDate(0, 0, 1), SessionDetails{})
+	// Either side of the week
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, monday.Add(-time.Minute), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, monday.AddDate(0, 0, 7), SessionDetails{})
+
+	got, err := store.GetMinutesByDay(monday, 7)
+	if err != nil {
+		t.Fatalf("GetMinutesByDay: %v", err)
+	}
+	if want := []int{25, 0, 50, 0, 0, 0, 30}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMinutesByDay = %v, want %v", got, want)
+	}
+}
+
+func TestLocalStoreDeleteQuotesBySubject(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	store.AddQuoteWithSubjects("Go one", "", []string{"GoLang"})
+	store.AddQuoteWithSubjects("Go and music", "", []string{"Music", "GoLang"})
+	store.AddQuoteWithSubjects("Music only", "", []string{"Music"})
+	store.AddQuoteWithSubjects("General", "", nil)
+
+	deleted, err := store.DeleteQuotesBySubject("GoLang")
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeleteQuotesBySubject = %d, %v; want 2, nil", deleted, err)
+	}
+
+	quotes, _ := store.GetAllQuotes()
+	var left []string
+	for _, q := range quotes {
+		left = append(left, q.Text)
+	}
+	sort.Strings(left)
+	if want := []string{"General", "Music only"}; !reflect.DeepEqual(left, want) {
+		t.Errorf("remaining quotes = %v, want %v", left, want)
+	}
+
+	if deleted, _ := store.DeleteQuotesBySubject(""); deleted != 0 {
+		t.Errorf("empty subject deleted %d quotes", deleted)
+	}
+
+	// A failed save leaves the quotes in memory as they were
+	store.path = filepath.Join(store.path, "not-a-dir", "data.json")
+	if _, err := store.DeleteQuotesBySubject("Music"); err == nil {
+		t.Fatal("expected the save to fail")
+	}
+	if n, _ := store.CountQuotes(); n != 2 {
+		t.Errorf("%d quotes left after a failed delete, want 2", n)
+	}
+}
+
+func TestLocalStoreFailedSaveRollsBack(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	quote, _ := store.AddQuoteWithSubjects("Wyrd bið ful aræd", "", nil)
+	store.SetSetting(SettingDisplayMode, "quotes")
+
+	store.path = filepath.Join(store.path, "not-a-dir", "data.json")
+	for name, change := range map[string]func() error{
+		"add quote":    func() error { _, err := store.AddQuoteWithSubjects("Hwæt", "", nil); return err },
+		"favorite":     func() error { return store.SetQuoteFavorite(quote.ID, true) },
+		"delete quote": func() error { return store.DeleteQuote(quote.ID) },
+		"create session": func() error {
+			_, err := store.CreateSessionWithDetails(primitive.NilObjectID, "Go", 25, StatusCompleted, time.Now(), SessionDetails{})
+			return err
+		},
+		"set setting": func() error { return store.SetSetting(SettingDisplayMode, "poems") },
+	} {
+		if change() == nil {
+			t.Errorf("%s: expected the save to fail", name)
+		}
+	}
+
+	// Memory still matches the file
+	quotes, _ := store.GetAllQuotes()
+	if len(quotes) != 1 || quotes[0].Favorite {
+		t.Errorf("quotes after failed saves = %+v, want the one unfavorited quote", quotes)
+	}
+	if sessions, _ := store.GetRecentSessions(0); len(sessions) != 0 {
+		t.Errorf("%d sessions after a failed save, want 0", len(sessions))
+	}
+	if mode, _ := store.GetSetting(SettingDisplayMode); mode != "quotes" {
+		t.Errorf("display mode = %q after a failed save, want quotes", mode)
+	}
+}
+
+func TestLocalStoreStrictQuoteFilter(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	store.AddQuoteWithSubjects("Go one", "", []string{"GoLang"})
+	store.AddQuoteWithSubjects("General", "", nil)
+
+	for i := 0; i < 20; i++ {
+		q, err := store.GetRandomQuoteMatching(QuoteFilter{Subject: "GoLang", Strict: true})
+		if err != nil || q == nil || q.Text != "Go one" {
+			t.Fatalf("strict GoLang quote = %v, %v; want Go one", q, err)
+		}
+	}
+
+	if q, _ := store.GetRandomQuoteMatching(QuoteFilter{Subject: "Music", Strict: true}); q != nil {
+		t.Errorf("strict Music quote = %q, want none", q.Text)
+	}
+}
+
+func TestLocalStoreSessionReflection(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	session, _ := store.CreateSessionWithDetails(primitive.NewObjectID(), "Go", 25, StatusCompleted, time.Now(), SessionDetails{})
+	if err := store.SetSessionReflection(session.ID, 4, "Flowed well"); err != nil {
+		t.Fatalf("SetSessionReflection: %v", err)
+	}
+
+	sessions, _ := store.GetRecentSessions(1)
+	if sessions[0].Rating != 4 || sessions[0].Note != "Flowed well" {
+		t.Errorf("got rating %d, note %q; want 4, %q", sessions[0].Rating, sessions[0].Note, "Flowed well")
+	}
+
+	var invalid *ValidationError
+	if err := store.SetSessionReflection(session.ID, MaxRating+1, ""); !errors.As(err, &invalid) {
+		t.Errorf("rating above MaxRating: got %v, want *ValidationError", err)
+	}
+}
+
+func TestLocalStoreAverageRating(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	subjectID := primitive.NewObjectID()
+	for _, rating := range []int{5, 2, 0} {
+		session, _ := store.CreateSessionWithDetails(subjectID, "Go", 25, StatusCompleted, time.Now(), SessionDetails{})
+		if rating > 0 {
+			store.SetSessionReflection(session.ID, rating, "")
+		}
+	}
+
+	// The unrated session is excluded rather than counted as zero
+	if avg, _ := store.GetAverageRating("Go"); avg != 3.5 {
+		t.Errorf("GetAverageRating(Go) = %v, want 3.5", avg)
+	}
+	if avg, _ := store.GetAverageRating("Music"); avg != 0 {
+		t.Errorf("GetAverageRating(Music) = %v, want 0", avg)
+	}
+}
+
+func TestLocalStoreMinutesSince(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	id := primitive.NewObjectID()
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, monday.Add(-time.Minute), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusCompleted, monday, SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 50, StatusCompleted, monday.Add(30*time.Hour), SessionDetails{})
+	store.CreateSessionWithDetails(id, "Go", 25, StatusAbandoned, monday.Add(time.Hour), SessionDetails{})
+
+	if got, _ := store.GetMinutesSince(monday); got != 75 {
+		t.Errorf("GetMinutesSince = %d, want 75", got)
+	}
+}
+
+func TestLocalStoreSessionStatsRange(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	now := time.Now()
+	add := func(daysAgo, minutes int, status SessionStatus) {
+		at := now.AddDate(0, 0, -daysAgo)
+		store.AddSessionIfNotExists(Session{
+			SubjectName: "Go", Duration: minutes, Status: status,
+			StartedAt: at.Add(-time.Duration(minutes) * time.Minute), CompletedAt: at, PausedSeconds: 60,
+		})
+	}
+	add(0, 25, StatusCompleted)
+	add(1, 25, StatusCompleted)
+	add(2, 10, StatusAbandoned)
+	add(20, 50, StatusCompleted)
+
+	stats, err := store.GetSessionStatsRange(now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SessionStats{TotalSessions: 3, CompletedSessions: 2, AbandonedSessions: 1, TotalMinutes: 50, PausedSeconds: 180, CurrentStreak: 2, LongestStreak: 2}
+	if *stats != want {
+		t.Errorf("last 7 days = %+v, want %+v", *stats, want)
+	}
+
+	all, _ := store.GetSessionStats()
+	if all.TotalSessions != 4 || all.TotalMinutes != 100 {
+		t.Errorf("all time = %d sessions, %dm; want 4, 100m", all.TotalSessions, all.TotalMinutes)
+	}
+}
+
+func TestQuoteOfTheDay(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+	previous := active
+	Use(store)
+	defer Use(previous)
+
+	morning := time.Date(2026, 3, 14, 8, 0, 0, 0, time.Local)
+	if q, err := quoteOfTheDay(morning); err != nil || q != nil {
+		t.Fatalf("no quotes: got %v, %v; want nil, nil", q, err)
+	}
+
+	for _, text := range []string{"First", "Second", "Third"} {
+		if _, err := store.AddQuoteWithSubjects(text, "", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first, err := quoteOfTheDay(morning)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evening, _ := quoteOfTheDay(morning.Add(12 * time.Hour))
+	if evening.Text != first.Text {
+		t.Errorf("same day: got %q then %q", first.Text, evening.Text)
+	}
+	tomorrow, _ := quoteOfTheDay(morning.AddDate(0, 0, 1))
+	if tomorrow.Text == first.Text {
+		t.Errorf("next day repeated %q", first.Text)
+	}
+}
+
+func TestLocalStoreMinutesByTag(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	id := primitive.NewObjectID()
+	now := time.Now()
+	sess, _ := store.CreateSessionWithDetails(id, "Writing", 25, StatusCompleted, now, SessionDetails{Tags: []string{" Blog ", "novel", "blog"}})
+	store.CreateSessionWithDetails(id, "Writing", 50, StatusCompleted, now, SessionDetails{Tags: []string{"novel"}})
+	store.CreateSessionWithDetails(id, "Writing", 25, StatusAbandoned, now, SessionDetails{Tags: []string{"blog"}})
+	store.CreateSessionWithDetails(id, "Writing", 25, StatusCompleted, now, SessionDetails{})
+
+	if want := []string{"blog", "novel"}; !reflect.DeepEqual(sess.Tags, want) {
+		t.Errorf("saved tags = %q, want %q", sess.Tags, want)
+	}
+
+	got, err := store.GetMinutesByTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int{"blog": 25, "novel": 75}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMinutesByTag = %v, want %v", got, want)
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	if got := ParseTags("  ,  "); got != nil {
+		t.Errorf("blank input should give no tags, got %q", got)
+	}
+	if got, want := ParseTags("Blog,  Short   Story ,blog"), []string{"blog", "short story"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTags = %q, want %q", got, want)
+	}
+}
+
+func TestQuoteMatchKey(t *testing.T) {
+	if a, b := QuoteMatchKey("Wyrd bið ful aræd."), QuoteMatchKey("  wyrd BIÐ ful-aræd "); a != b {
+		t.Errorf("near-duplicates should share a key: %q vs %q", a, b)
+	}
+	if a, b := QuoteMatchKey("Fortune favours the bold"), QuoteMatchKey("Fortune favours the brave"); a == b {
+		t.Errorf("different quotes share key %q", a)
+	}
+}
+
+func TestLocalStoreNearDuplicateQuotes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	legacy := `{"Quotes": [{"ID": "65f000000000000000000001", "Text": "Fate goes ever as fate must."}]}`
+	if err := os.WriteFile(path, []byte(legacy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store, err := OpenLocalStore(path)
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	got, added, err := store.AddQuoteIfNotExists("fate goes ever as fate must", "", nil)
+	if err != nil || added {
+		t.Fatalf("AddQuoteIfNotExists added=%v err=%v, want the existing quote", added, err)
+	}
+	if got.Text != "Fate goes ever as fate must." {
+		t.Errorf("returned %q, want the original text", got.Text)
+	}
+
+	if _, err := store.AddQuoteWithSubjects("FATE goes ever, as fate must!", "", nil); !errors.Is(err, ErrDuplicateQuote) {
+		t.Errorf("AddQuoteWithSubjects err = %v, want ErrDuplicateQuote", err)
+	}
+	if _, added, _ := store.AddQuoteIfNotExists("Fate often saves an undoomed man", "", nil); !added {
+		t.Error("a different quote should be added")
+	}
+}
+
+func TestLocalStoreStreakFreezeSetting(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	// Today and two days ago, with yesterday missed
+	now := time.Now()
+	for _, daysAgo := range []int{0, 2} {
+		at := now.AddDate(0, 0, -daysAgo)
+		store.AddSessionIfNotExists(Session{SubjectName: "Go", Duration: 25, Status: StatusCompleted, StartedAt: at, CompletedAt: at})
+	}
+
+	stats, err := store.GetSessionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CurrentStreak != 1 {
+		t.Errorf("without freezes: current streak = %d, want 1", stats.CurrentStreak)
+	}
+
+	if err := store.SetSetting(SettingStreakFreezes, "1"); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = store.GetSessionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CurrentStreak != 2 {
+		t.Errorf("with a freeze: current streak = %d, want 2", stats.CurrentStreak)
+	}
+}
+
+func TestLocalStoreStreaksCountAbandoned(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatalf("OpenLocalStore: %v", err)
+	}
+
+	// Completed today and two days ago, abandoned yesterday
+	now := time.Now()
+	for daysAgo, status := range []SessionStatus{StatusCompleted, StatusAbandoned, StatusCompleted} {
+		at := now.AddDate(0, 0, -daysAgo)
+		store.AddSessionIfNotExists(Session{SubjectName: "Go", Duration: 25, Status: status, StartedAt: at, CompletedAt: at})
+	}
+
+	stats, err := store.GetSessionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CurrentStreak != 1 {
+		t.Errorf("by default: current streak = %d, want 1", stats.CurrentStreak)
+	}
+
+	if err := store.SetSetting(SettingStreakAbandon, "true"); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = store.GetSessionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CurrentStreak != 3 || stats.LongestStreak != 3 {
+		t.Errorf("counting abandoned: streaks = %d/%d, want 3/3", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if stats.CompletedSessions != 2 || stats.AbandonedSessions != 1 {
+		t.Errorf("session counts should not change: %d completed, %d abandoned", stats.CompletedSessions, stats.AbandonedSessions)
+	}
+}
+
+func TestDurationsBreaksOff(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Use(store)
+	defer Use(MongoStore{})
+
+	if d, _ := GetDurations(); d.ShortBreak != 5 || d.LongBreak != 15 {
+		t.Errorf("unset breaks = %d/%d, want the 5/15 defaults", d.ShortBreak, d.LongBreak)
+	}
+	if err := SetDurations(50, 0, 0, 4); err != nil {
+		t.Fatal(err)
+	}
+	d, err := GetDurations()
+	if err != nil || d.Work != 50 || d.ShortBreak != 0 || d.LongBreak != 0 {
+		t.Errorf("GetDurations = %+v, %v; want 50 minutes with breaks off", d, err)
+	}
+}
@@ -3,12 +3,17 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
 
 var (
@@ -18,11 +23,63 @@ var (
 
 const (
 	DefaultDatabase = "beot"
+	// DefaultTimeout bounds a single query when BEOT_DB_TIMEOUT is unset
+	DefaultTimeout = 5 * time.Second
 )
 
+// Timeout bounds each database call. Connecting and multi-stage aggregations
+// get twice this budget. Override with BEOT_DB_TIMEOUT (e.g. "2s" or "10").
+var Timeout = DefaultTimeout
+
+// MongoStore is the MongoDB storage backend. It uses the package-level
+// Client/Database set by Connect.
+type MongoStore struct{}
+
+// ErrNotConnected is returned by db functions when Connect has not succeeded
+var ErrNotConnected = errors.New("not connected to database")
+
+// MigrationErr records a startup migration that failed. Connect still
+// succeeds without it; callers report it so the problem is not hidden.
+var MigrationErr error
+
 func init() {
 	// Load .env file if it exists (silently ignore if not found)
 	godotenv.Load()
+	// Then the config file, for anything neither the environment nor .env sets
+	loadConfig()
+
+	if t, ok := parseTimeout(os.Getenv("BEOT_DB_TIMEOUT")); ok {
+		Timeout = t
+	}
+}
+
+// parseTimeout accepts a Go duration ("1500ms", "3s") or a bare number of seconds
+func parseTimeout(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	var d time.Duration
+	if secs, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if d, err = time.ParseDuration(value); err != nil {
+		return 0, false
+	}
+	if d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// queryContext returns a context bounded by Timeout for a single query
+func queryContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), Timeout)
+}
+
+// longQueryContext returns a context with double the query budget, for
+// connecting, dropping collections and full-history aggregations
+func longQueryContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 2*Timeout)
 }
 
 // getMongoURI returns the MongoDB URI from environment variable
@@ -31,17 +88,45 @@ func getMongoURI() (string, error) {
 	if uri == "" {
 		return "", errors.New("BEOT_MONGODB_URI environment variable is required. Create a .env file or set it in your environment")
 	}
-	return uri, nil
+	return uri, validateMongoURI(uri, "BEOT_MONGODB_URI")
+}
+
+// ErrInvalidMongoURI is returned before connecting when the connection
+// string is malformed
+var ErrInvalidMongoURI = errors.New("invalid MongoDB connection string")
+
+// validateMongoURI checks uri the way the driver will, so a typo gets a clear
+// message naming where it came from rather than failing deep in Connect. The
+// URI itself is left out of the message as it may hold a password. A failed
+// SRV lookup is not a malformed URI; Connect reports that as a connection
+// problem.
+func validateMongoURI(uri, source string) error {
+	_, err := connstring.ParseAndValidate(uri)
+	var dnsErr *net.DNSError
+	if err == nil || errors.As(err, &dnsErr) {
+		return nil
+	}
+	return fmt.Errorf("%w in %s: %v\nIt should look like mongodb://localhost:27017 or mongodb+srv://<username>:<password>@<cluster>.mongodb.net/",
+		ErrInvalidMongoURI, source, err)
+}
+
+// databaseName returns BEOT_DATABASE, or DefaultDatabase if unset
+func databaseName() string {
+	if name := os.Getenv("BEOT_DATABASE"); name != "" {
+		return name
+	}
+	return DefaultDatabase
 }
 
-// Connect establishes the MongoDB connection
+// Connect establishes the MongoDB connection, using the profile named by
+// BEOT_PROFILE if one is set
 func Connect() error {
-	uri, err := getMongoURI()
+	uri, name, err := resolveProfile(fileConfig.Profiles)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := longQueryContext()
 	defer cancel()
 
 	clientOptions := options.Client().ApplyURI(uri)
@@ -50,22 +135,82 @@ func Connect() error {
 		return err
 	}
 
-	// Ping to verify connection
+	// Ping to verify connection. A failed client keeps monitoring the server
+	// in the background, so close it, on a fresh deadline since the ping may
+	// have used up ctx.
 	if err := client.Ping(ctx, nil); err != nil {
+		closeCtx, closeCancel := queryContext()
+		defer closeCancel()
+		client.Disconnect(closeCtx)
 		return err
 	}
 
 	Client = client
-	Database = client.Database(DefaultDatabase)
+	Database = client.Database(name)
+
+	// Best-effort: without the key, near-duplicate checks still match exact
+	// text, so a failure is recorded rather than refusing to start
+	if err := migrateQuoteKeys(); err != nil {
+		MigrationErr = fmt.Errorf("indexing quotes for duplicate checks: %w", err)
+	}
 	return nil
 }
 
+// collection returns the named collection, or ErrNotConnected if there is no database
+func collection(name string) (*mongo.Collection, error) {
+	if Database == nil {
+		return nil, ErrNotConnected
+	}
+	return Database.Collection(name), nil
+}
+
+// Ping checks the server is still reachable. The driver reconnects on its
+// own, so a successful ping means queries will work again.
+func (MongoStore) Ping() error {
+	if Client == nil {
+		return ErrNotConnected
+	}
+	ctx, cancel := queryContext()
+	defer cancel()
+	return Client.Ping(ctx, nil)
+}
+
+// IsConnectionError reports whether err means the database cannot be reached,
+// as opposed to a problem with a single query
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, ErrNotConnected) ||
+		errors.Is(err, mongo.ErrClientDisconnected) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		mongo.IsNetworkError(err) ||
+		mongo.IsTimeout(err)
+}
+
 // Disconnect closes the MongoDB connection
 func Disconnect() error {
 	if Client != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := queryContext()
 		defer cancel()
 		return Client.Disconnect(ctx)
 	}
 	return nil
 }
+
+// ClearContent drops the quotes, subjects and poems collections
+func (MongoStore) ClearContent() error {
+	ctx, cancel := longQueryContext()
+	defer cancel()
+
+	for _, name := range []string{"quotes", "subjects", "poems"} {
+		coll, err := collection(name)
+		if err != nil {
+			return err
+		}
+		if err := coll.Drop(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
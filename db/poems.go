@@ -1,7 +1,6 @@
 package db
 
 import (
-	"context"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -15,19 +14,48 @@ type Poem struct {
 	ModernEnglish string             `bson:"modern_english"`
 	Source        string             `bson:"source"`
 	LineRef       string             `bson:"line_ref,omitempty"`
+	Subjects      []string           `bson:"subjects,omitempty"` // Empty = general (shown for all)
 	CreatedAt     time.Time          `bson:"created_at"`
 }
 
-func PoemsCollection() *mongo.Collection {
-	return Database.Collection("poems")
+func PoemsCollection() (*mongo.Collection, error) {
+	return collection("poems")
 }
 
 // GetAllPoems returns all poems from the database
-func GetAllPoems() ([]Poem, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) GetAllPoems() ([]Poem, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	cursor, err := PoemsCollection().Find(ctx, bson.M{})
+	coll, err := PoemsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var poems []Poem
+	if err := cursor.All(ctx, &poems); err != nil {
+		return nil, err
+	}
+	return poems, nil
+}
+
+// GetPoemsForSubject returns the poems tagged with a subject plus general ones
+func (MongoStore) GetPoemsForSubject(subjectName string) ([]Poem, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := PoemsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, subjectFilter(subjectName))
 	if err != nil {
 		return nil, err
 	}
@@ -42,14 +70,26 @@ func GetAllPoems() ([]Poem, error) {
 
 // GetRandomPoem returns a random poem using MongoDB aggregation
 func GetRandomPoem() (*Poem, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return GetRandomPoemForSubject("")
+}
+
+// GetRandomPoemForSubject returns a random poem for a specific subject
+// It includes poems tagged with that subject OR general poems (no subjects)
+func (MongoStore) GetRandomPoemForSubject(subjectName string) (*Poem, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
 	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: subjectFilter(subjectName)}},
 		{{Key: "$sample", Value: bson.D{{Key: "size", Value: 1}}}},
 	}
 
-	cursor, err := PoemsCollection().Aggregate(ctx, pipeline)
+	coll, err := PoemsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -66,9 +106,14 @@ func GetRandomPoem() (*Poem, error) {
 	return &poems[0], nil
 }
 
-// AddPoem inserts a new poem passage
+// AddPoem inserts a new poem passage (general, shown for all subjects)
 func AddPoem(oldEnglish, modernEnglish, source, lineRef string) (*Poem, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return AddPoemWithSubjects(oldEnglish, modernEnglish, source, lineRef, nil)
+}
+
+// AddPoemWithSubjects inserts a new poem passage with subject tags
+func (MongoStore) AddPoemWithSubjects(oldEnglish, modernEnglish, source, lineRef string, subjects []string) (*Poem, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
 	poem := Poem{
@@ -76,10 +121,16 @@ func AddPoem(oldEnglish, modernEnglish, source, lineRef string) (*Poem, error) {
 		ModernEnglish: modernEnglish,
 		Source:        source,
 		LineRef:       lineRef,
+		Subjects:      subjects,
 		CreatedAt:     time.Now(),
 	}
 
-	result, err := PoemsCollection().InsertOne(ctx, poem)
+	coll, err := PoemsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := coll.InsertOne(ctx, poem)
 	if err != nil {
 		return nil, err
 	}
@@ -89,13 +140,18 @@ func AddPoem(oldEnglish, modernEnglish, source, lineRef string) (*Poem, error) {
 }
 
 // AddPoemIfNotExists creates a poem only if one with the same source and lineRef doesn't exist
-func AddPoemIfNotExists(oldEnglish, modernEnglish, source, lineRef string) (*Poem, bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) AddPoemIfNotExists(oldEnglish, modernEnglish, source, lineRef string, subjects []string) (*Poem, bool, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
+	coll, err := PoemsCollection()
+	if err != nil {
+		return nil, false, err
+	}
+
 	// Check if poem already exists
 	var existing Poem
-	err := PoemsCollection().FindOne(ctx, bson.M{"source": source, "line_ref": lineRef}).Decode(&existing)
+	err = coll.FindOne(ctx, bson.M{"source": source, "line_ref": lineRef}).Decode(&existing)
 	if err == nil {
 		return &existing, false, nil
 	}
@@ -109,10 +165,11 @@ func AddPoemIfNotExists(oldEnglish, modernEnglish, source, lineRef string) (*Poe
 		ModernEnglish: modernEnglish,
 		Source:        source,
 		LineRef:       lineRef,
+		Subjects:      subjects,
 		CreatedAt:     time.Now(),
 	}
 
-	result, err := PoemsCollection().InsertOne(ctx, poem)
+	result, err := coll.InsertOne(ctx, poem)
 	if err != nil {
 		return nil, false, err
 	}
@@ -121,19 +178,54 @@ func AddPoemIfNotExists(oldEnglish, modernEnglish, source, lineRef string) (*Poe
 	return &poem, true, nil
 }
 
+// UpdatePoem replaces a poem's text, source and line reference
+func (MongoStore) UpdatePoem(id primitive.ObjectID, oldEnglish, modernEnglish, source, lineRef string) error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := PoemsCollection()
+	if err != nil {
+		return err
+	}
+
+	result, err := coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"old_english":    oldEnglish,
+		"modern_english": modernEnglish,
+		"source":         source,
+		"line_ref":       lineRef,
+	}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
 // DeletePoem removes a poem by ID
-func DeletePoem(id primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) DeletePoem(id primitive.ObjectID) error {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	_, err := PoemsCollection().DeleteOne(ctx, bson.M{"_id": id})
+	coll, err := PoemsCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.DeleteOne(ctx, bson.M{"_id": id})
 	return err
 }
 
 // CountPoems returns the number of poems
-func CountPoems() (int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) CountPoems() (int64, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	return PoemsCollection().CountDocuments(ctx, bson.M{})
+	coll, err := PoemsCollection()
+	if err != nil {
+		return 0, err
+	}
+
+	return coll.CountDocuments(ctx, bson.M{})
 }
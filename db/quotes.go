@@ -1,12 +1,17 @@
 package db
 
 import (
-	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type Quote struct {
@@ -14,19 +19,67 @@ type Quote struct {
 	Text      string             `bson:"text"`
 	Source    string             `bson:"source,omitempty"`
 	Subjects  []string           `bson:"subjects,omitempty"` // Empty = general (shown for all)
+	Favorite  bool               `bson:"favorite,omitempty"`
 	CreatedAt time.Time          `bson:"created_at"`
+	// QuoteMatchKey of Text, unique across quotes; absent on a near-duplicate
+	// that predates the index
+	Normalized string `bson:"normalized,omitempty"`
 }
 
-func QuotesCollection() *mongo.Collection {
-	return Database.Collection("quotes")
+// ErrDuplicateQuote is returned when adding a quote that matches an existing
+// one once case, punctuation and spacing are ignored
+var ErrDuplicateQuote = errors.New("a quote like this already exists")
+
+// QuoteMatchKey reduces text to lowercase letters and digits, so quotes that
+// differ only in case, punctuation or spacing share a key
+func QuoteMatchKey(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func QuotesCollection() (*mongo.Collection, error) {
+	return collection("quotes")
 }
 
 // GetAllQuotes returns all quotes from the database
-func GetAllQuotes() ([]Quote, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) GetAllQuotes() ([]Quote, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := QuotesCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var quotes []Quote
+	if err := cursor.All(ctx, &quotes); err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+// GetQuotesForSubject returns the quotes tagged with a subject plus general ones
+func (MongoStore) GetQuotesForSubject(subjectName string) ([]Quote, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	cursor, err := QuotesCollection().Find(ctx, bson.M{})
+	coll, err := QuotesCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, subjectFilter(subjectName))
 	if err != nil {
 		return nil, err
 	}
@@ -47,22 +100,103 @@ func GetRandomQuote() (*Quote, error) {
 // GetRandomQuoteForSubject returns a random quote for a specific subject
 // It includes quotes tagged with that subject OR general quotes (no subjects)
 func GetRandomQuoteForSubject(subjectName string) (*Quote, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	return GetRandomQuoteFiltered(subjectName, false)
+}
+
+// subjectFilter matches documents tagged with subjectName OR general ones
+// (empty/null subjects). An empty subjectName matches everything.
+func subjectFilter(subjectName string) bson.M {
+	if subjectName == "" {
+		return bson.M{}
+	}
+	return bson.M{
+		"$or": []bson.M{
+			{"subjects": subjectName},
+			{"subjects": bson.M{"$exists": false}},
+			{"subjects": bson.M{"$size": 0}},
+			{"subjects": nil},
+		},
+	}
+}
+
+// QuoteFilter narrows random quote selection
+type QuoteFilter struct {
+	Subject       string               // Quotes for this subject plus general ones; "" = all
+	Strict        bool                 // Leave out general quotes, keeping only those tagged Subject
+	FavoritesOnly bool                 // Only quotes marked as favorites
+	ExcludeIDs    []primitive.ObjectID // Recently shown quotes to skip
+}
+
+// GetRandomQuoteFiltered returns a random quote for a subject, optionally
+// restricted to quotes marked as favorites
+func GetRandomQuoteFiltered(subjectName string, favoritesOnly bool) (*Quote, error) {
+	return GetRandomQuoteMatching(QuoteFilter{Subject: subjectName, FavoritesOnly: favoritesOnly})
+}
+
+// GetRandomQuoteExcluding returns a random quote for a subject that is not in excludeIDs
+func GetRandomQuoteExcluding(subjectName string, excludeIDs []primitive.ObjectID) (*Quote, error) {
+	return GetRandomQuoteMatching(QuoteFilter{Subject: subjectName, ExcludeIDs: excludeIDs})
+}
+
+// GetQuoteOfTheDay returns the same quote all day and the next one
+// tomorrow. It returns nil, without an error, when there are no quotes.
+func GetQuoteOfTheDay() (*Quote, error) {
+	return quoteOfTheDay(time.Now())
+}
+
+// quoteOfTheDay picks by day of the year from the quotes in ID order, which
+// is creation order, so the choice is stable between calls
+func quoteOfTheDay(day time.Time) (*Quote, error) {
+	quotes, err := GetAllQuotes()
+	if err != nil || len(quotes) == 0 {
+		return nil, err
+	}
+	sort.Slice(quotes, func(i, j int) bool {
+		return quotes[i].ID.Hex() < quotes[j].ID.Hex()
+	})
+	q := quotes[day.YearDay()%len(quotes)]
+	return &q, nil
+}
 
-	// Build filter: quotes for this subject OR general quotes (empty/null subjects)
-	var filter bson.M
-	if subjectName != "" {
-		filter = bson.M{
-			"$or": []bson.M{
-				{"subjects": subjectName},
-				{"subjects": bson.M{"$exists": false}},
-				{"subjects": bson.M{"$size": 0}},
-				{"subjects": nil},
-			},
+// Matches reports whether q passes the filter, for choosing among quotes
+// already in memory
+func (f QuoteFilter) Matches(q Quote) bool {
+	if f.FavoritesOnly && !q.Favorite {
+		return false
+	}
+	if !matchesSubject(q.Subjects, f.Subject) {
+		return false
+	}
+	if f.Strict && f.Subject != "" && !containsString(q.Subjects, f.Subject) {
+		return false
+	}
+	for _, id := range f.ExcludeIDs {
+		if q.ID == id {
+			return false
 		}
-	} else {
-		filter = bson.M{}
+	}
+	return true
+}
+
+// matchesSubject mirrors subjectFilter for in-memory filtering
+func matchesSubject(subjects []string, subjectName string) bool {
+	return subjectName == "" || len(subjects) == 0 || containsString(subjects, subjectName)
+}
+
+// GetRandomQuoteMatching returns a random quote passing the filter, or nil if none do
+func (MongoStore) GetRandomQuoteMatching(f QuoteFilter) (*Quote, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	filter := subjectFilter(f.Subject)
+	if f.Strict && f.Subject != "" {
+		filter = bson.M{"subjects": f.Subject}
+	}
+	if f.FavoritesOnly {
+		filter["favorite"] = true
+	}
+	if len(f.ExcludeIDs) > 0 {
+		filter["_id"] = bson.M{"$nin": f.ExcludeIDs}
 	}
 
 	pipeline := mongo.Pipeline{
@@ -70,7 +204,12 @@ func GetRandomQuoteForSubject(subjectName string) (*Quote, error) {
 		{{Key: "$sample", Value: bson.D{{Key: "size", Value: 1}}}},
 	}
 
-	cursor, err := QuotesCollection().Aggregate(ctx, pipeline)
+	coll, err := QuotesCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -93,18 +232,27 @@ func AddQuote(text, source string) (*Quote, error) {
 }
 
 // AddQuoteWithSubjects inserts a new quote with subject tags
-func AddQuoteWithSubjects(text, source string, subjects []string) (*Quote, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) AddQuoteWithSubjects(text, source string, subjects []string) (*Quote, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
 	quote := Quote{
-		Text:      text,
-		Source:    source,
-		Subjects:  subjects,
-		CreatedAt: time.Now(),
+		Text:       text,
+		Source:     source,
+		Subjects:   subjects,
+		CreatedAt:  time.Now(),
+		Normalized: QuoteMatchKey(text),
+	}
+
+	coll, err := QuotesCollection()
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := QuotesCollection().InsertOne(ctx, quote)
+	result, err := coll.InsertOne(ctx, quote)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicateQuote
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -113,30 +261,49 @@ func AddQuoteWithSubjects(text, source string, subjects []string) (*Quote, error
 	return &quote, nil
 }
 
-// AddQuoteIfNotExists creates a quote only if one with the same text doesn't exist
-func AddQuoteIfNotExists(text, source string, subjects []string) (*Quote, bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// AddQuoteIfNotExists creates a quote only if no existing one has the same
+// QuoteMatchKey, returning the existing quote otherwise
+func (MongoStore) AddQuoteIfNotExists(text, source string, subjects []string) (*Quote, bool, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	// Check if quote already exists
-	var existing Quote
-	err := QuotesCollection().FindOne(ctx, bson.M{"text": text}).Decode(&existing)
+	coll, err := QuotesCollection()
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := QuoteMatchKey(text)
+	findExisting := func() (*Quote, error) {
+		var existing Quote
+		err := coll.FindOne(ctx, bson.M{"$or": []bson.M{{"normalized": key}, {"text": text}}}).Decode(&existing)
+		if err != nil {
+			return nil, err
+		}
+		return &existing, nil
+	}
+
+	existing, err := findExisting()
 	if err == nil {
-		return &existing, false, nil
+		return existing, false, nil
 	}
 	if err != mongo.ErrNoDocuments {
 		return nil, false, err
 	}
 
-	// Create new quote
 	quote := Quote{
-		Text:      text,
-		Source:    source,
-		Subjects:  subjects,
-		CreatedAt: time.Now(),
+		Text:       text,
+		Source:     source,
+		Subjects:   subjects,
+		CreatedAt:  time.Now(),
+		Normalized: key,
 	}
 
-	result, err := QuotesCollection().InsertOne(ctx, quote)
+	result, err := coll.InsertOne(ctx, quote)
+	if mongo.IsDuplicateKeyError(err) {
+		// Added by someone else since the lookup
+		existing, err := findExisting()
+		return existing, false, err
+	}
 	if err != nil {
 		return nil, false, err
 	}
@@ -145,19 +312,115 @@ func AddQuoteIfNotExists(text, source string, subjects []string) (*Quote, bool,
 	return &quote, true, nil
 }
 
+// SetQuoteFavorite marks or unmarks a quote as a favorite
+func (MongoStore) SetQuoteFavorite(id primitive.ObjectID, favorite bool) error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := QuotesCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"favorite": favorite}})
+	return err
+}
+
 // DeleteQuote removes a quote by ID
-func DeleteQuote(id primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) DeleteQuote(id primitive.ObjectID) error {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	_, err := QuotesCollection().DeleteOne(ctx, bson.M{"_id": id})
+	coll, err := QuotesCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.DeleteOne(ctx, bson.M{"_id": id})
 	return err
 }
 
+// DeleteQuotesBySubject removes every quote tagged with subjectName and
+// returns how many went. General quotes (no subjects) never match.
+func (MongoStore) DeleteQuotesBySubject(subjectName string) (int64, error) {
+	if subjectName == "" {
+		return 0, nil
+	}
+
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := QuotesCollection()
+	if err != nil {
+		return 0, err
+	}
+
+	// Matches the name anywhere in the subjects array
+	result, err := coll.DeleteMany(ctx, bson.M{"subjects": subjectName})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 // CountQuotes returns the number of quotes
-func CountQuotes() (int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) CountQuotes() (int64, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	return QuotesCollection().CountDocuments(ctx, bson.M{})
+	coll, err := QuotesCollection()
+	if err != nil {
+		return 0, err
+	}
+
+	return coll.CountDocuments(ctx, bson.M{})
+}
+
+// migrateQuoteKeys makes the normalized field unique, then backfills it on
+// quotes saved before it existed. Of several near-duplicates only the oldest
+// gets the key, which the index enforces; AddQuoteIfNotExists finds the
+// others through it. Once every quote has a key there is nothing to read.
+func migrateQuoteKeys() error {
+	ctx, cancel := longQueryContext()
+	defer cancel()
+
+	coll, err := QuotesCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "normalized", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"normalized": bson.M{"$type": "string"}}),
+	})
+	if err != nil {
+		return fmt.Errorf("creating the unique quote key index: %w", err)
+	}
+
+	var quotes []Quote
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := coll.Find(ctx, bson.M{"normalized": bson.M{"$exists": false}}, opts)
+	if err != nil {
+		return err
+	}
+	if err := cursor.All(ctx, &quotes); err != nil {
+		return err
+	}
+
+	for _, q := range quotes {
+		key := QuoteMatchKey(q.Text)
+		if key == "" {
+			continue
+		}
+		_, err := coll.UpdateByID(ctx, q.ID, bson.M{"$set": bson.M{"normalized": key}})
+		if mongo.IsDuplicateKeyError(err) {
+			continue // An older quote already has this key
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -25,27 +27,79 @@ type Session struct {
 	Status      SessionStatus      `bson:"status"`
 	StartedAt   time.Time          `bson:"started_at"`
 	CompletedAt time.Time          `bson:"completed_at,omitempty"`
+	// Content visible when the session ended (absent on older sessions)
+	LastQuoteText   string   `bson:"last_quote_text,omitempty"`
+	LastQuoteSource string   `bson:"last_quote_source,omitempty"`
+	LastPoemRef     string   `bson:"last_poem_ref,omitempty"`
+	PausedSeconds   int      `bson:"paused_seconds,omitempty"` // Total time spent paused
+	Intention       string   `bson:"intention,omitempty"`      // What the user vowed to do, if anything
+	Rating          int      `bson:"rating,omitempty"`         // Self-rating 1-5 from the reflection prompt, 0 = unrated
+	Note            string   `bson:"note,omitempty"`           // Reflection note
+	Tags            []string `bson:"tags,omitempty"`           // Optional labels within the subject, lowercase
+	Segment         bool     `bson:"segment,omitempty"`        // An early part of a block split by switching subject
 }
 
-func SessionsCollection() *mongo.Collection {
-	return Database.Collection("sessions")
+// MaxRating is the top of the session self-rating scale
+const MaxRating = 5
+
+// SessionDetails holds optional information recorded with a session
+type SessionDetails struct {
+	QuoteText     string // Quote on screen when the session ended
+	QuoteSource   string
+	PoemRef       string // Poem on screen, e.g. "Beowulf, lines 572-573"
+	PausedSeconds int
+	Intention     string    // Stated goal for the session, optional
+	Tags          []string  // Free-form labels, normalized on save
+	EndedAt       time.Time // When the session finished; zero means now
+	Segment       bool      // Saved on switching subject; the block's last part is not a segment
+}
+
+// newSession builds a session from its details, ending now unless the
+// details say otherwise (a session logged after the fact)
+func newSession(subjectID primitive.ObjectID, subjectName string, duration int, status SessionStatus, startedAt time.Time, details SessionDetails) Session {
+	completedAt := details.EndedAt
+	if completedAt.IsZero() {
+		completedAt = time.Now()
+	}
+	return Session{
+		SubjectID:       subjectID,
+		SubjectName:     subjectName,
+		Duration:        duration,
+		Status:          status,
+		StartedAt:       startedAt,
+		CompletedAt:     completedAt,
+		LastQuoteText:   details.QuoteText,
+		LastQuoteSource: details.QuoteSource,
+		LastPoemRef:     details.PoemRef,
+		PausedSeconds:   details.PausedSeconds,
+		Intention:       details.Intention,
+		Tags:            NormalizeTags(details.Tags),
+		Segment:         details.Segment,
+	}
+}
+
+func SessionsCollection() (*mongo.Collection, error) {
+	return collection("sessions")
 }
 
 // CreateSession saves a new session
 func CreateSession(subjectID primitive.ObjectID, subjectName string, duration int, status SessionStatus, startedAt time.Time) (*Session, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return CreateSessionWithDetails(subjectID, subjectName, duration, status, startedAt, SessionDetails{})
+}
+
+// CreateSessionWithDetails saves a new session along with its optional details
+func (MongoStore) CreateSessionWithDetails(subjectID primitive.ObjectID, subjectName string, duration int, status SessionStatus, startedAt time.Time, details SessionDetails) (*Session, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	session := Session{
-		SubjectID:   subjectID,
-		SubjectName: subjectName,
-		Duration:    duration,
-		Status:      status,
-		StartedAt:   startedAt,
-		CompletedAt: time.Now(),
+	session := newSession(subjectID, subjectName, duration, status, startedAt, details)
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := SessionsCollection().InsertOne(ctx, session)
+	result, err := coll.InsertOne(ctx, session)
 	if err != nil {
 		return nil, err
 	}
@@ -54,16 +108,90 @@ func CreateSession(subjectID primitive.ObjectID, subjectName string, duration in
 	return &session, nil
 }
 
+// AddSessionIfNotExists stores a previously recorded session as-is, keeping
+// its timestamps, unless one for the same subject and start time exists.
+// A new ID is always assigned. Used when restoring a backup.
+func (MongoStore) AddSessionIfNotExists(session Session) (*Session, bool, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var existing Session
+	err = coll.FindOne(ctx, bson.M{
+		"subject_name": session.SubjectName,
+		"started_at":   session.StartedAt,
+	}).Decode(&existing)
+	if err == nil {
+		return &existing, false, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, false, err
+	}
+
+	session.ID = primitive.NilObjectID
+	result, err := coll.InsertOne(ctx, session)
+	if err != nil {
+		return nil, false, err
+	}
+	session.ID = result.InsertedID.(primitive.ObjectID)
+	return &session, true, nil
+}
+
+// SetSessionReflection records how a session went: a rating from 1 to
+// MaxRating (0 leaves it unrated) and an optional note
+func (MongoStore) SetSessionReflection(id primitive.ObjectID, rating int, note string) error {
+	if rating < 0 || rating > MaxRating {
+		return &ValidationError{Field: "rating", Message: fmt.Sprintf("must be between 1 and %d", MaxRating)}
+	}
+
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"rating": rating,
+		"note":   note,
+	}})
+	return err
+}
+
+// DeleteSession removes a session by ID
+func (MongoStore) DeleteSession(id primitive.ObjectID) error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
 // GetRecentSessions returns the most recent sessions
-func GetRecentSessions(limit int) ([]Session, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) GetRecentSessions(limit int) ([]Session, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
 	opts := options.Find().
 		SetSort(bson.D{{Key: "completed_at", Value: -1}}).
 		SetLimit(int64(limit))
 
-	cursor, err := SessionsCollection().Find(ctx, bson.M{}, opts)
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Find(ctx, bson.M{}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -76,31 +204,345 @@ func GetRecentSessions(limit int) ([]Session, error) {
 	return sessions, nil
 }
 
+// GetLongestSession returns the longest completed session, or nil if none
+// has been completed. Ties go to the earliest; segments are left out.
+func (MongoStore) GetLongestSession() (*Session, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.FindOne().SetSort(bson.D{
+		{Key: "duration", Value: -1},
+		{Key: "completed_at", Value: 1},
+	})
+
+	var session Session
+	err = coll.FindOne(ctx, wholeBlocks(bson.M{"status": StatusCompleted}), opts).Decode(&session)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetAverageRating returns the mean reflection rating of a subject's sessions.
+// Unrated sessions are left out; 0 means none have been rated.
+func (MongoStore) GetAverageRating(subjectName string) (float64, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "subject_name", Value: subjectName},
+			{Key: "rating", Value: bson.D{{Key: "$gte", Value: 1}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "average", Value: bson.D{{Key: "$avg", Value: "$rating"}}},
+		}}},
+	}
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return 0, err
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Average float64 `bson:"average"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return 0, err
+		}
+	}
+	return result.Average, cursor.Err()
+}
+
+// findCompleted returns completed sessions with only the projected fields set
+func findCompleted(projection bson.M) ([]Session, error) {
+	ctx, cancel := longQueryContext()
+	defer cancel()
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetProjection(projection)
+	cursor, err := coll.Find(ctx, bson.M{"status": StatusCompleted}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// sessionsByHour counts sessions by the local hour (0-23) they started in,
+// leaving out segments so a block split by switching subject counts once
+func sessionsByHour(sessions []Session) map[int]int {
+	byHour := make(map[int]int)
+	for _, s := range sessions {
+		if !s.Segment {
+			byHour[s.StartedAt.Local().Hour()]++
+		}
+	}
+	return byHour
+}
+
+// GetSessionsByHour counts completed sessions by the local hour they started.
+// Bucketing happens here rather than with $hour, which works in UTC.
+func (MongoStore) GetSessionsByHour() (map[int]int, error) {
+	sessions, err := findCompleted(bson.M{"started_at": 1, "segment": 1})
+	if err != nil {
+		return nil, err
+	}
+	return sessionsByHour(sessions), nil
+}
+
+// minutesByWeekday sums session minutes by the local weekday they started,
+// Monday first
+func minutesByWeekday(sessions []Session) [7]int {
+	var byDay [7]int
+	for _, s := range sessions {
+		day := (int(s.StartedAt.Local().Weekday()) + 6) % 7
+		byDay[day] += s.Duration
+	}
+	return byDay
+}
+
+// GetMinutesByWeekday sums completed minutes by local weekday, Monday first
+func (MongoStore) GetMinutesByWeekday() ([7]int, error) {
+	sessions, err := findCompleted(bson.M{"started_at": 1, "duration": 1})
+	if err != nil {
+		return [7]int{}, err
+	}
+	return minutesByWeekday(sessions), nil
+}
+
+// monthBounds returns local midnight on the first of the month and of the next
+func monthBounds(year int, month time.Month) (start, end time.Time) {
+	start = time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// minutesByDayOfMonth sums session minutes by the local day of the month
+// they started, for sessions started in the given month
+func minutesByDayOfMonth(sessions []Session, year int, month time.Month) map[int]int {
+	start, end := monthBounds(year, month)
+	byDay := make(map[int]int)
+	for _, s := range sessions {
+		if s.StartedAt.Before(start) || !s.StartedAt.Before(end) {
+			continue
+		}
+		byDay[s.StartedAt.Local().Day()] += s.Duration
+	}
+	return byDay
+}
+
+// GetActivityForMonth sums completed minutes by local day of the month.
+// Days without a completed session are left out.
+func (MongoStore) GetActivityForMonth(year int, month time.Month) (map[int]int, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := monthBounds(year, month)
+	filter := bson.M{
+		"status":     StatusCompleted,
+		"started_at": bson.M{"$gte": start, "$lt": end},
+	}
+	opts := options.Find().SetProjection(bson.M{"started_at": 1, "duration": 1})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return minutesByDayOfMonth(sessions, year, month), nil
+}
+
+// minutesByDay sums session minutes into len(days) buckets, one per local
+// day from start, by the day they started. Sessions outside are ignored.
+func minutesByDay(sessions []Session, start time.Time, days int) []int {
+	minutes := make([]int, days)
+	for _, s := range sessions {
+		for i := range minutes {
+			if !s.StartedAt.Before(start.AddDate(0, 0, i)) && s.StartedAt.Before(start.AddDate(0, 0, i+1)) {
+				minutes[i] += s.Duration
+				break
+			}
+		}
+	}
+	return minutes
+}
+
+// GetMinutesByDay sums completed minutes for each of days local days from
+// start, which should be local midnight
+func (MongoStore) GetMinutesByDay(start time.Time, days int) ([]int, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		"status":     StatusCompleted,
+		"started_at": bson.M{"$gte": start, "$lt": start.AddDate(0, 0, days)},
+	}
+	opts := options.Find().SetProjection(bson.M{"started_at": 1, "duration": 1})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return minutesByDay(sessions, start, days), nil
+}
+
+// CountCompletedSince counts completed blocks started at or after since.
+// Segments are left out, so a block split by switching subject counts once.
+func (MongoStore) CountCompletedSince(since time.Time) (int, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := coll.CountDocuments(ctx, bson.M{
+		"status":     StatusCompleted,
+		"started_at": bson.M{"$gte": since},
+		"segment":    bson.M{"$ne": true},
+	})
+	return int(n), err
+}
+
+// wholeBlocks narrows filter to sessions that count as a block, leaving out
+// the earlier segments of a block split by switching subject
+func wholeBlocks(filter bson.M) bson.M {
+	blocks := bson.M{"segment": bson.M{"$ne": true}}
+	for key, value := range filter {
+		blocks[key] = value
+	}
+	return blocks
+}
+
+// GetMinutesSince sums completed minutes of sessions started at or after since
+func (MongoStore) GetMinutesSince(since time.Time) (int, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "status", Value: StatusCompleted},
+			{Key: "started_at", Value: bson.D{{Key: "$gte", Value: since}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "minutes", Value: bson.D{{Key: "$sum", Value: "$duration"}}},
+		}}},
+	}
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return 0, err
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Minutes int `bson:"minutes"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return 0, err
+		}
+	}
+	return result.Minutes, cursor.Err()
+}
+
 // GetSessionStats returns statistics about sessions
 type SessionStats struct {
 	TotalSessions     int
 	CompletedSessions int
 	AbandonedSessions int
 	TotalMinutes      int
+	PausedSeconds     int // Across all sessions
 	CurrentStreak     int
 	LongestStreak     int
 }
 
-func GetSessionStats() (*SessionStats, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (s MongoStore) GetSessionStats() (*SessionStats, error) {
+	return s.GetSessionStatsRange(time.Time{})
+}
+
+// GetSessionStatsRange counts sessions and minutes for sessions that
+// finished at or after since; a zero since means all time. Streaks are
+// always all-time, since a streak cut off at the range start would mislead.
+func (MongoStore) GetSessionStatsRange(since time.Time) (*SessionStats, error) {
+	ctx, cancel := longQueryContext()
 	defer cancel()
 
 	stats := &SessionStats{}
 
 	// Count total sessions
-	total, err := SessionsCollection().CountDocuments(ctx, bson.M{})
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	inRange := bson.M{}
+	completedInRange := bson.M{"status": StatusCompleted}
+	if !since.IsZero() {
+		inRange["completed_at"] = bson.M{"$gte": since}
+		completedInRange["completed_at"] = bson.M{"$gte": since}
+	}
+
+	// A block split by switching subject counts once; its minutes all add up below
+	total, err := coll.CountDocuments(ctx, wholeBlocks(inRange))
 	if err != nil {
 		return nil, err
 	}
 	stats.TotalSessions = int(total)
 
 	// Count completed sessions
-	completed, err := SessionsCollection().CountDocuments(ctx, bson.M{"status": StatusCompleted})
+	completed, err := coll.CountDocuments(ctx, wholeBlocks(completedInRange))
 	if err != nil {
 		return nil, err
 	}
@@ -109,14 +551,14 @@ func GetSessionStats() (*SessionStats, error) {
 
 	// Sum total minutes from completed sessions
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.D{{Key: "status", Value: StatusCompleted}}}},
+		{{Key: "$match", Value: completedInRange}},
 		{{Key: "$group", Value: bson.D{
 			{Key: "_id", Value: nil},
 			{Key: "total", Value: bson.D{{Key: "$sum", Value: "$duration"}}},
 		}}},
 	}
 
-	cursor, err := SessionsCollection().Aggregate(ctx, pipeline)
+	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +576,33 @@ func GetSessionStats() (*SessionStats, error) {
 		}
 	}
 
+	// Sum paused time across all sessions
+	pausedPipeline := mongo.Pipeline{
+		{{Key: "$match", Value: inRange}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "paused", Value: bson.D{{Key: "$sum", Value: "$paused_seconds"}}},
+		}}},
+	}
+
+	pausedCursor, err := coll.Aggregate(ctx, pausedPipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer pausedCursor.Close(ctx)
+
+	var pausedResults []bson.M
+	if err := pausedCursor.All(ctx, &pausedResults); err != nil {
+		return nil, err
+	}
+	if len(pausedResults) > 0 {
+		if paused, ok := pausedResults[0]["paused"].(int32); ok {
+			stats.PausedSeconds = int(paused)
+		} else if paused, ok := pausedResults[0]["paused"].(int64); ok {
+			stats.PausedSeconds = int(paused)
+		}
+	}
+
 	// Calculate streaks
 	stats.CurrentStreak, stats.LongestStreak = calculateStreaks(ctx)
 
@@ -142,9 +611,19 @@ func GetSessionStats() (*SessionStats, error) {
 
 // calculateStreaks determines current and longest streaks
 func calculateStreaks(ctx context.Context) (current, longest int) {
-	// Get all completed sessions, sorted by date descending
+	// Get the sessions that count (completed, and abandoned if the setting
+	// says so), sorted by date descending
 	opts := options.Find().SetSort(bson.D{{Key: "completed_at", Value: -1}})
-	cursor, err := SessionsCollection().Find(ctx, bson.M{"status": StatusCompleted}, opts)
+	coll, err := SessionsCollection()
+	if err != nil {
+		return 0, 0
+	}
+
+	filter := bson.M{"status": StatusCompleted}
+	if loadStreaksCountAbandoned(MongoStore{}.GetSetting) {
+		filter = bson.M{"status": bson.M{"$in": []SessionStatus{StatusCompleted, StatusAbandoned}}}
+	}
+	cursor, err := coll.Find(ctx, filter, opts)
 	if err != nil {
 		return 0, 0
 	}
@@ -155,94 +634,114 @@ func calculateStreaks(ctx context.Context) (current, longest int) {
 		return 0, 0
 	}
 
-	if len(sessions) == 0 {
-		return 0, 0
-	}
+	return CalculateStreaksWithFreezes(sessionDays(sessions), time.Now(), loadStreakFreezes(MongoStore{}.GetSetting))
+}
 
-	// Track unique days with completed sessions
-	days := make(map[string]bool)
-	for _, s := range sessions {
-		dayKey := s.CompletedAt.Format("2006-01-02")
-		days[dayKey] = true
+// sessionDays returns when each session was completed, for streak counting
+func sessionDays(sessions []Session) []time.Time {
+	days := make([]time.Time, len(sessions))
+	for i, s := range sessions {
+		days[i] = s.CompletedAt
 	}
+	return days
+}
 
-	// Convert to sorted slice of dates
-	var sortedDays []time.Time
-	for dayStr := range days {
-		t, _ := time.Parse("2006-01-02", dayStr)
-		sortedDays = append(sortedDays, t)
+// CalculateStreaksFrom returns the current and longest runs of consecutive
+// calendar days in days, judged in now's time zone so a session just after
+// local midnight counts for the new day. The current streak is kept alive
+// until a whole day passes without a session, so it still counts yesterday
+// before today's first session. days may be unsorted and repeat a day.
+func CalculateStreaksFrom(days []time.Time, now time.Time) (current, longest int) {
+	return CalculateStreaksWithFreezes(days, now, StreakFreezes{})
+}
+
+// CalculateStreaksWithFreezes is CalculateStreaksFrom with streak freezes:
+// a single missed day is bridged while its week has freezes left, so the run
+// carries on across it. Bridged days add nothing to the streak's length, and
+// two missed days in a row always end it. Freezes are spent newest first.
+func CalculateStreaksWithFreezes(days []time.Time, now time.Time, freezes StreakFreezes) (current, longest int) {
+	// Number calendar days so consecutive days differ by exactly one,
+	// whatever DST does to the hours in between
+	dayNumber := func(t time.Time) int64 {
+		y, m, d := t.In(now.Location()).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
 	}
 
-	// Sort descending (most recent first)
-	for i := 0; i < len(sortedDays)-1; i++ {
-		for j := i + 1; j < len(sortedDays); j++ {
-			if sortedDays[j].After(sortedDays[i]) {
-				sortedDays[i], sortedDays[j] = sortedDays[j], sortedDays[i]
-			}
+	seen := make(map[int64]bool)
+	var numbers []int64
+	for _, t := range days {
+		if n := dayNumber(t); !seen[n] {
+			seen[n] = true
+			numbers = append(numbers, n)
 		}
 	}
-
-	// Calculate current streak (from today or yesterday)
-	today := time.Now().Truncate(24 * time.Hour)
-	yesterday := today.AddDate(0, 0, -1)
-
-	currentStreak := 0
-	if len(sortedDays) > 0 {
-		mostRecent := sortedDays[0].Truncate(24 * time.Hour)
-		if mostRecent.Equal(today) || mostRecent.Equal(yesterday) {
-			currentStreak = 1
-			for i := 1; i < len(sortedDays); i++ {
-				prev := sortedDays[i-1].Truncate(24 * time.Hour)
-				curr := sortedDays[i].Truncate(24 * time.Hour)
-				diff := prev.Sub(curr).Hours() / 24
-				if diff == 1 {
-					currentStreak++
-				} else {
-					break
-				}
-			}
+	if len(numbers) == 0 {
+		return 0, 0
+	}
+	// Most recent first
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+
+	// bridge spends a freeze on the day between two active days, if its week has one left
+	used := make(map[int64]int)
+	bridge := func(newer, older int64) bool {
+		if newer-older != 2 || freezes.PerWeek <= 0 {
+			return false
 		}
+		week := freezes.week(older + 1)
+		if used[week] >= freezes.PerWeek {
+			return false
+		}
+		used[week]++
+		return true
 	}
 
-	// Calculate longest streak
-	longestStreak := 0
-	if len(sortedDays) > 0 {
-		streak := 1
-		for i := 1; i < len(sortedDays); i++ {
-			prev := sortedDays[i-1].Truncate(24 * time.Hour)
-			curr := sortedDays[i].Truncate(24 * time.Hour)
-			diff := prev.Sub(curr).Hours() / 24
-			if diff == 1 {
-				streak++
-			} else {
-				if streak > longestStreak {
-					longestStreak = streak
-				}
-				streak = 1
-			}
+	// The latest run is current if it reaches today, or yesterday, or a
+	// frozen yesterday. Checked first so today's gap gets the first freeze.
+	today := dayNumber(now)
+	isCurrent := today-numbers[0] <= 1 || bridge(today, numbers[0])
+
+	run, latestRun := 1, 0
+	for i := 1; i <= len(numbers); i++ {
+		if i < len(numbers) && (numbers[i-1]-numbers[i] == 1 || bridge(numbers[i-1], numbers[i])) {
+			run++
+			continue
 		}
-		if streak > longestStreak {
-			longestStreak = streak
+		if latestRun == 0 {
+			latestRun = run
 		}
+		longest = max(longest, run)
+		run = 1
 	}
 
-	return currentStreak, longestStreak
+	if isCurrent {
+		current = latestRun
+	}
+	return current, longest
 }
 
-// GetSessionsBySubject returns session counts per subject
-func GetSessionsBySubject() (map[string]int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetSessionsBySubject returns session counts per subject. A block split by
+// switching subject counts for the subject it ended on.
+func (MongoStore) GetSessionsBySubject() (map[string]int, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.D{{Key: "status", Value: StatusCompleted}}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: "status", Value: StatusCompleted},
+			{Key: "segment", Value: bson.D{{Key: "$ne", Value: true}}},
+		}}},
 		{{Key: "$group", Value: bson.D{
 			{Key: "_id", Value: "$subject_name"},
 			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
 		}}},
 	}
 
-	cursor, err := SessionsCollection().Aggregate(ctx, pipeline)
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
@@ -263,3 +762,45 @@ func GetSessionsBySubject() (map[string]int, error) {
 
 	return results, nil
 }
+
+// GetMinutesBySubject returns total completed minutes per subject
+func (MongoStore) GetMinutesBySubject() (map[string]int, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "status", Value: StatusCompleted}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$subject_name"},
+			{Key: "minutes", Value: bson.D{{Key: "$sum", Value: "$duration"}}},
+		}}},
+	}
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := make(map[string]int)
+	for cursor.Next(ctx) {
+		var result bson.M
+		if err := cursor.Decode(&result); err != nil {
+			continue
+		}
+		if name, ok := result["_id"].(string); ok {
+			if minutes, ok := result["minutes"].(int32); ok {
+				results[name] = int(minutes)
+			} else if minutes, ok := result["minutes"].(int64); ok {
+				results[name] = int(minutes)
+			}
+		}
+	}
+
+	return results, nil
+}
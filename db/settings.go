@@ -0,0 +1,241 @@
+package db
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Setting is a single key/value user preference
+type Setting struct {
+	Key       string    `bson:"_id"`
+	Value     string    `bson:"value"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// Setting keys
+const (
+	SettingDisplayMode   = "display_mode"
+	SettingFavoritesOnly = "favorites_only"
+	SettingWorkMinutes   = "work_minutes"
+	SettingShortBreak    = "short_break_minutes"
+	SettingLongBreak     = "long_break_minutes"
+	SettingCycleLength   = "cycle_length"
+	SettingAlert         = "alert"
+	SettingMinimalTimer  = "minimal_timer"
+	SettingSkipSplash    = "skip_splash"
+	SettingWeeklyGoal    = "weekly_goal_minutes"
+	SettingNightMode     = "night_mode_after" // "HH:MM" local time, empty = off
+	SettingQuoteMaxChars = "quote_max_chars"
+	SettingWeekStart     = "week_start"              // "monday" or "sunday"
+	SettingStreakFreezes = "streak_freezes_per_week" // 0 = off
+	SettingStreakAbandon = "streaks_count_abandoned" // "true" lets abandoned sessions keep a streak going
+	SettingAutoBreaks    = "auto_start_breaks"       // "false" waits for a key before each break
+	SettingAutoWork      = "auto_start_work"         // "true" starts the next block when a break ends
+	SettingReducedMotion = "reduced_motion"          // "true" stops the timer's pulse animation
+	SettingNotifyOff     = "notifications_off"       // "true" stops desktop notifications
+)
+
+// DefaultSessionMinutes is the focus block length used when nothing is configured
+const DefaultSessionMinutes = 25
+
+// Durations holds the Pomodoro timings, all in minutes except Cycle
+type Durations struct {
+	Work       int
+	ShortBreak int
+	LongBreak  int
+	Cycle      int // Focus blocks before a long break
+
+	// Phase changes; the zero value starts breaks straight away and waits
+	// for a key before the next focus block
+	HoldBreaks    bool // Wait for a key before each break
+	AutoStartWork bool // Start the next focus block as soon as a break ends
+}
+
+// defaultWorkMinutes returns BEOT_DEFAULT_MINUTES, or DefaultSessionMinutes
+func defaultWorkMinutes() int {
+	if n, err := strconv.Atoi(os.Getenv("BEOT_DEFAULT_MINUTES")); err == nil && n > 0 {
+		return n
+	}
+	return DefaultSessionMinutes
+}
+
+// DefaultDurations returns the classic Pomodoro timings, with the focus
+// block taken from BEOT_DEFAULT_MINUTES when set
+func DefaultDurations() Durations {
+	return Durations{
+		Work:       defaultWorkMinutes(),
+		ShortBreak: 5,
+		LongBreak:  15,
+		Cycle:      4,
+	}
+}
+
+func SettingsCollection() (*mongo.Collection, error) {
+	return collection("settings")
+}
+
+// GetSetting returns the value stored for key, or "" if it has never been set
+func (MongoStore) GetSetting(key string) (string, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	var setting Setting
+	coll, err := SettingsCollection()
+	if err != nil {
+		return "", err
+	}
+
+	err = coll.FindOne(ctx, bson.M{"_id": key}).Decode(&setting)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return setting.Value, nil
+}
+
+// GetBoolSetting returns a setting interpreted as a boolean (default false)
+func GetBoolSetting(key string) (bool, error) {
+	value, err := GetSetting(key)
+	if err != nil {
+		return false, err
+	}
+	return value == "true", nil
+}
+
+// SetBoolSetting stores a boolean setting
+func SetBoolSetting(key string, value bool) error {
+	if value {
+		return SetSetting(key, "true")
+	}
+	return SetSetting(key, "false")
+}
+
+// SetSetting stores value under key, replacing any previous value
+func (MongoStore) SetSetting(key, value string) error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	setting := Setting{
+		Key:       key,
+		Value:     value,
+		UpdatedAt: time.Now(),
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	coll, err := SettingsCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.ReplaceOne(ctx, bson.M{"_id": key}, setting, opts)
+	return err
+}
+
+// GetIntSetting returns a setting interpreted as an int, or def if unset or invalid
+func GetIntSetting(key string, def int) (int, error) {
+	value, err := GetSetting(key)
+	if err != nil {
+		return def, err
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return def, nil
+	}
+	return n, nil
+}
+
+// getBreakMinutes reads a break length like GetIntSetting, except that a
+// stored 0 is kept: it turns breaks off
+func getBreakMinutes(key string, def int) (int, error) {
+	value, err := GetSetting(key)
+	if err != nil {
+		return def, err
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return def, nil
+	}
+	return n, nil
+}
+
+// GetDurations returns the configured timings, using defaults for anything unset
+func GetDurations() (Durations, error) {
+	d := DefaultDurations()
+	var err error
+	if d.Work, err = GetIntSetting(SettingWorkMinutes, d.Work); err != nil {
+		return DefaultDurations(), err
+	}
+	if d.ShortBreak, err = getBreakMinutes(SettingShortBreak, d.ShortBreak); err != nil {
+		return DefaultDurations(), err
+	}
+	if d.LongBreak, err = getBreakMinutes(SettingLongBreak, d.LongBreak); err != nil {
+		return DefaultDurations(), err
+	}
+	if d.Cycle, err = GetIntSetting(SettingCycleLength, d.Cycle); err != nil {
+		return DefaultDurations(), err
+	}
+	autoBreaks, err := GetSetting(SettingAutoBreaks)
+	if err != nil {
+		return DefaultDurations(), err
+	}
+	d.HoldBreaks = autoBreaks == "false"
+	if d.AutoStartWork, err = GetBoolSetting(SettingAutoWork); err != nil {
+		return DefaultDurations(), err
+	}
+	return d, nil
+}
+
+// GetWeeklyGoal returns the weekly focus target in minutes, or 0 if none is set
+func GetWeeklyGoal() (int, error) {
+	return GetIntSetting(SettingWeeklyGoal, 0)
+}
+
+// SetWeeklyGoal stores the weekly focus target; 0 turns it off
+func SetWeeklyGoal(minutes int) error {
+	return SetSetting(SettingWeeklyGoal, strconv.Itoa(minutes))
+}
+
+// GetQuoteMaxChars returns how many characters of a quote the timer shows
+// before truncating it, or 0 for no limit
+func GetQuoteMaxChars() (int, error) {
+	return GetIntSetting(SettingQuoteMaxChars, 0)
+}
+
+// SetQuoteMaxChars stores the quote length limit; 0 turns it off
+func SetQuoteMaxChars(chars int) error {
+	return SetSetting(SettingQuoteMaxChars, strconv.Itoa(chars))
+}
+
+// SetAutoStart stores whether breaks and focus blocks start without a key press
+func SetAutoStart(breaks, work bool) error {
+	if err := SetBoolSetting(SettingAutoBreaks, breaks); err != nil {
+		return err
+	}
+	return SetBoolSetting(SettingAutoWork, work)
+}
+
+// SetDurations stores the Pomodoro timings
+func SetDurations(work, shortBreak, longBreak, cycle int) error {
+	values := []struct {
+		key   string
+		value int
+	}{
+		{SettingWorkMinutes, work},
+		{SettingShortBreak, shortBreak},
+		{SettingLongBreak, longBreak},
+		{SettingCycleLength, cycle},
+	}
+	for _, v := range values {
+		if err := SetSetting(v.key, strconv.Itoa(v.value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Store is a storage backend for subjects, quotes, poems, sessions and settings.
+// MongoStore is the default; LocalStore keeps everything in a JSON file.
+type Store interface {
+	// Quotes
+	GetAllQuotes() ([]Quote, error)
+	GetQuotesForSubject(subjectName string) ([]Quote, error)
+	GetRandomQuoteMatching(f QuoteFilter) (*Quote, error)
+	AddQuoteWithSubjects(text, source string, subjects []string) (*Quote, error)
+	AddQuoteIfNotExists(text, source string, subjects []string) (*Quote, bool, error)
+	SetQuoteFavorite(id primitive.ObjectID, favorite bool) error
+	DeleteQuote(id primitive.ObjectID) error
+	DeleteQuotesBySubject(subjectName string) (int64, error)
+	CountQuotes() (int64, error)
+
+	// Poems
+	GetAllPoems() ([]Poem, error)
+	GetPoemsForSubject(subjectName string) ([]Poem, error)
+	GetRandomPoemForSubject(subjectName string) (*Poem, error)
+	AddPoemWithSubjects(oldEnglish, modernEnglish, source, lineRef string, subjects []string) (*Poem, error)
+	AddPoemIfNotExists(oldEnglish, modernEnglish, source, lineRef string, subjects []string) (*Poem, bool, error)
+	UpdatePoem(id primitive.ObjectID, oldEnglish, modernEnglish, source, lineRef string) error
+	DeletePoem(id primitive.ObjectID) error
+	CountPoems() (int64, error)
+
+	// Subjects
+	GetSubjects(includeArchived bool) ([]Subject, error)
+	GetSubjectByID(id primitive.ObjectID) (*Subject, error)
+	GetSubjectByName(name string) (*Subject, error)
+	AddSubjectWithDuration(name, icon string, duration int) (*Subject, error)
+	AddSubjectIfNotExists(name, icon string) (*Subject, bool, error)
+	SetSubjectOrder(ids []primitive.ObjectID) error
+	SetSubjectArchived(id primitive.ObjectID, archived bool) error
+	SetSubjectStrictQuotes(id primitive.ObjectID, strict bool) error
+	DeleteSubject(id primitive.ObjectID) error
+
+	// Sessions
+	CreateSessionWithDetails(subjectID primitive.ObjectID, subjectName string, duration int, status SessionStatus, startedAt time.Time, details SessionDetails) (*Session, error)
+	AddSessionIfNotExists(session Session) (*Session, bool, error)
+	SetSessionReflection(id primitive.ObjectID, rating int, note string) error
+	DeleteSession(id primitive.ObjectID) error
+	GetRecentSessions(limit int) ([]Session, error)
+	GetLongestSession() (*Session, error)
+	GetAverageRating(subjectName string) (float64, error)
+	GetSessionStats() (*SessionStats, error)
+	GetSessionStatsRange(since time.Time) (*SessionStats, error)
+	GetSessionsBySubject() (map[string]int, error)
+	GetMinutesBySubject() (map[string]int, error)
+	GetMinutesByTag() (map[string]int, error)
+	GetMinutesSince(since time.Time) (int, error)
+	CountCompletedSince(since time.Time) (int, error)
+	GetSessionsByHour() (map[int]int, error)
+	GetMinutesByWeekday() ([7]int, error)
+	GetActivityForMonth(year int, month time.Month) (map[int]int, error)
+	GetMinutesByDay(start time.Time, days int) ([]int, error)
+
+	// Active session recovery
+	SaveActiveSession(a ActiveSession) error
+	LoadActiveSession() (*ActiveSession, error)
+	ClearActiveSession() error
+
+	// Settings
+	GetSetting(key string) (string, error)
+	SetSetting(key, value string) error
+
+	// ClearContent removes all quotes, subjects and poems (used by seed --clean)
+	ClearContent() error
+
+	// Ping checks the backend is reachable
+	Ping() error
+}
+
+// active is the backend used by the package-level functions
+var active Store = MongoStore{}
+
+// Use replaces the active storage backend
+func Use(s Store) {
+	active = s
+}
+
+// Backend returns the name of the backend selected by BEOT_STORAGE ("mongo" or "local")
+func Backend() string {
+	if strings.EqualFold(os.Getenv("BEOT_STORAGE"), "local") {
+		return "local"
+	}
+	return "mongo"
+}
+
+// Open connects to the backend selected by BEOT_STORAGE (default mongo)
+func Open() error {
+	if ConfigErr != nil {
+		return fmt.Errorf("reading config file: %w", ConfigErr)
+	}
+	if Backend() == "local" {
+		store, err := OpenLocalStore("")
+		if err != nil {
+			return err
+		}
+		Use(store)
+		return nil
+	}
+
+	if err := Connect(); err != nil {
+		return err
+	}
+	Use(MongoStore{})
+	return nil
+}
+
+// Close releases the active backend
+func Close() error {
+	if _, ok := active.(MongoStore); ok {
+		return Disconnect()
+	}
+	return nil
+}
+
+// Package-level functions delegate to the active backend
+
+func GetAllQuotes() ([]Quote, error) { return active.GetAllQuotes() }
+
+func GetQuotesForSubject(subjectName string) ([]Quote, error) {
+	return active.GetQuotesForSubject(subjectName)
+}
+
+func GetRandomQuoteMatching(f QuoteFilter) (*Quote, error) { return active.GetRandomQuoteMatching(f) }
+
+// AddQuoteWithSubjects validates and normalizes the quote before storing it
+func AddQuoteWithSubjects(text, source string, subjects []string) (*Quote, error) {
+	text, source, err := ValidateQuote(text, source)
+	if err != nil {
+		return nil, err
+	}
+	return active.AddQuoteWithSubjects(text, source, subjects)
+}
+
+// AddQuoteIfNotExists validates and normalizes the quote before storing it
+func AddQuoteIfNotExists(text, source string, subjects []string) (*Quote, bool, error) {
+	text, source, err := ValidateQuote(text, source)
+	if err != nil {
+		return nil, false, err
+	}
+	return active.AddQuoteIfNotExists(text, source, subjects)
+}
+
+func SetQuoteFavorite(id primitive.ObjectID, favorite bool) error {
+	return active.SetQuoteFavorite(id, favorite)
+}
+
+func DeleteQuote(id primitive.ObjectID) error { return active.DeleteQuote(id) }
+
+func DeleteQuotesBySubject(subjectName string) (int64, error) {
+	return active.DeleteQuotesBySubject(subjectName)
+}
+
+func CountQuotes() (int64, error) { return active.CountQuotes() }
+
+func GetAllPoems() ([]Poem, error) { return active.GetAllPoems() }
+
+func GetPoemsForSubject(subjectName string) ([]Poem, error) {
+	return active.GetPoemsForSubject(subjectName)
+}
+
+func GetRandomPoemForSubject(subjectName string) (*Poem, error) {
+	return active.GetRandomPoemForSubject(subjectName)
+}
+
+func AddPoemWithSubjects(oldEnglish, modernEnglish, source, lineRef string, subjects []string) (*Poem, error) {
+	return active.AddPoemWithSubjects(oldEnglish, modernEnglish, source, lineRef, subjects)
+}
+
+func AddPoemIfNotExists(oldEnglish, modernEnglish, source, lineRef string, subjects []string) (*Poem, bool, error) {
+	return active.AddPoemIfNotExists(oldEnglish, modernEnglish, source, lineRef, subjects)
+}
+
+// UpdatePoem validates and normalizes the new text before replacing the
+// poem's; its subjects and creation time are kept
+func UpdatePoem(id primitive.ObjectID, oldEnglish, modernEnglish, source, lineRef string) error {
+	oldEnglish, modernEnglish, source, lineRef, err := ValidatePoem(oldEnglish, modernEnglish, source, lineRef)
+	if err != nil {
+		return err
+	}
+	return active.UpdatePoem(id, oldEnglish, modernEnglish, source, lineRef)
+}
+
+func DeletePoem(id primitive.ObjectID) error { return active.DeletePoem(id) }
+
+func CountPoems() (int64, error) { return active.CountPoems() }
+
+func GetSubjects(includeArchived bool) ([]Subject, error) { return active.GetSubjects(includeArchived) }
+
+func GetSubjectByID(id primitive.ObjectID) (*Subject, error) { return active.GetSubjectByID(id) }
+
+func GetSubjectByName(name string) (*Subject, error) { return active.GetSubjectByName(name) }
+
+func AddSubjectWithDuration(name, icon string, duration int) (*Subject, error) {
+	return active.AddSubjectWithDuration(name, icon, duration)
+}
+
+func AddSubjectIfNotExists(name, icon string) (*Subject, bool, error) {
+	return active.AddSubjectIfNotExists(name, icon)
+}
+
+func SetSubjectOrder(ids []primitive.ObjectID) error { return active.SetSubjectOrder(ids) }
+
+func SetSubjectArchived(id primitive.ObjectID, archived bool) error {
+	return active.SetSubjectArchived(id, archived)
+}
+
+func SetSubjectStrictQuotes(id primitive.ObjectID, strict bool) error {
+	return active.SetSubjectStrictQuotes(id, strict)
+}
+
+func DeleteSubject(id primitive.ObjectID) error { return active.DeleteSubject(id) }
+
+func CreateSessionWithDetails(subjectID primitive.ObjectID, subjectName string, duration int, status SessionStatus, startedAt time.Time, details SessionDetails) (*Session, error) {
+	return active.CreateSessionWithDetails(subjectID, subjectName, duration, status, startedAt, details)
+}
+
+func AddSessionIfNotExists(session Session) (*Session, bool, error) {
+	return active.AddSessionIfNotExists(session)
+}
+
+func SetSessionReflection(id primitive.ObjectID, rating int, note string) error {
+	return active.SetSessionReflection(id, rating, note)
+}
+
+func DeleteSession(id primitive.ObjectID) error { return active.DeleteSession(id) }
+
+func GetRecentSessions(limit int) ([]Session, error) { return active.GetRecentSessions(limit) }
+
+func GetLongestSession() (*Session, error) { return active.GetLongestSession() }
+
+func GetAverageRating(subjectName string) (float64, error) {
+	return active.GetAverageRating(subjectName)
+}
+
+func GetSessionStats() (*SessionStats, error) { return active.GetSessionStats() }
+
+func GetSessionStatsRange(since time.Time) (*SessionStats, error) {
+	return active.GetSessionStatsRange(since)
+}
+
+func GetSessionsBySubject() (map[string]int, error) { return active.GetSessionsBySubject() }
+
+func GetMinutesBySubject() (map[string]int, error) { return active.GetMinutesBySubject() }
+
+func GetMinutesByTag() (map[string]int, error) { return active.GetMinutesByTag() }
+
+func GetMinutesSince(since time.Time) (int, error) { return active.GetMinutesSince(since) }
+
+func CountCompletedSince(since time.Time) (int, error) { return active.CountCompletedSince(since) }
+
+func GetSessionsByHour() (map[int]int, error) { return active.GetSessionsByHour() }
+
+func GetMinutesByWeekday() ([7]int, error) { return active.GetMinutesByWeekday() }
+
+// GetActivityForMonth returns completed minutes by local day of the month
+func GetActivityForMonth(year int, month time.Month) (map[int]int, error) {
+	return active.GetActivityForMonth(year, month)
+}
+
+// GetMinutesByDay returns completed minutes for each local day from start
+func GetMinutesByDay(start time.Time, days int) ([]int, error) {
+	return active.GetMinutesByDay(start, days)
+}
+
+func SaveActiveSession(a ActiveSession) error { return active.SaveActiveSession(a) }
+
+func LoadActiveSession() (*ActiveSession, error) { return active.LoadActiveSession() }
+
+func ClearActiveSession() error { return active.ClearActiveSession() }
+
+// GetSetting returns the saved value for key, falling back to the config file
+func GetSetting(key string) (string, error) {
+	value, err := active.GetSetting(key)
+	if value == "" && err == nil {
+		value = configDefault(key)
+	}
+	return value, err
+}
+
+func SetSetting(key, value string) error { return active.SetSetting(key, value) }
+
+func ClearContent() error { return active.ClearContent() }
+
+func Ping() error { return active.Ping() }
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"strconv"
+	"time"
+)
+
+// MaxStreakFreezes caps how many missed days a week can bridge
+const MaxStreakFreezes = 3
+
+// StreakFreezes lets a streak survive a single missed day, a limited number
+// of times per calendar week. The zero value turns freezes off.
+type StreakFreezes struct {
+	PerWeek   int
+	WeekStart time.Weekday
+}
+
+// week numbers the calendar week holding a day number (days since the Unix
+// epoch, which fell on a Thursday)
+func (f StreakFreezes) week(day int64) int64 {
+	return (day + int64(time.Thursday) - int64(f.WeekStart) + 7) / 7
+}
+
+// GetStreakFreezesPerWeek returns how many missed days a week a streak may
+// bridge, 0 when freezes are off
+func GetStreakFreezesPerWeek() (int, error) {
+	n, err := GetIntSetting(SettingStreakFreezes, 0)
+	return min(n, MaxStreakFreezes), err
+}
+
+// SetStreakFreezesPerWeek stores the weekly freeze allowance; 0 turns freezes off
+func SetStreakFreezesPerWeek(n int) error {
+	return SetSetting(SettingStreakFreezes, strconv.Itoa(n))
+}
+
+// GetStreaksCountAbandoned reports whether abandoned sessions count towards
+// streaks as well as completed ones. Off by default.
+func GetStreaksCountAbandoned() (bool, error) {
+	return GetBoolSetting(SettingStreakAbandon)
+}
+
+// SetStreaksCountAbandoned chooses whether abandoned sessions count towards streaks
+func SetStreaksCountAbandoned(on bool) error {
+	return SetBoolSetting(SettingStreakAbandon, on)
+}
+
+// loadStreaksCountAbandoned reads the setting through get, like
+// loadStreakFreezes; unreadable means off
+func loadStreaksCountAbandoned(get func(key string) (string, error)) bool {
+	value, err := get(SettingStreakAbandon)
+	return err == nil && value == "true"
+}
+
+// countsTowardStreak reports whether a session's day joins the streak
+func countsTowardStreak(status SessionStatus, countAbandoned bool) bool {
+	return status == StatusCompleted || (countAbandoned && status == StatusAbandoned)
+}
+
+// loadStreakFreezes reads the freeze rules through get, the store's own
+// setting lookup. Streaks are best effort, so an unreadable setting means
+// no freezes rather than an error.
+func loadStreakFreezes(get func(key string) (string, error)) StreakFreezes {
+	f := StreakFreezes{WeekStart: time.Monday}
+	if value, err := get(SettingStreakFreezes); err == nil {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			f.PerWeek = min(n, MaxStreakFreezes)
+		}
+	}
+	if day, ok := WeekStartOverride(); ok {
+		f.WeekStart = day
+	} else if value, err := get(SettingWeekStart); err == nil {
+		if value == "" {
+			value = configDefault(SettingWeekStart)
+		}
+		f.WeekStart, _ = ParseWeekStart(value)
+	}
+	return f
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // Named zones for the DST cases, regardless of the host
+)
+
+func TestCalculateStreaksFrom(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(m time.Month, d, h, min int) time.Time {
+		return time.Date(2026, m, d, h, min, 0, 0, london)
+	}
+
+	tests := []struct {
+		name          string
+		days          []time.Time
+		now           time.Time
+		current, long int
+	}{
+		{"none", nil, at(3, 10, 12, 0), 0, 0},
+		{"late tonight then early tomorrow", []time.Time{at(3, 9, 23, 50), at(3, 10, 0, 10)}, at(3, 10, 9, 0), 2, 2},
+		{"yesterday keeps the streak alive", []time.Time{at(3, 8, 20, 0), at(3, 9, 20, 0)}, at(3, 10, 9, 0), 2, 2},
+		{"a skipped day resets it", []time.Time{at(3, 7, 20, 0), at(3, 8, 20, 0)}, at(3, 10, 9, 0), 0, 2},
+		{"gap inside the history", []time.Time{at(3, 1, 9, 0), at(3, 2, 9, 0), at(3, 3, 9, 0), at(3, 9, 9, 0), at(3, 10, 9, 0)}, at(3, 10, 22, 0), 2, 3},
+		{"repeats and order do not matter", []time.Time{at(3, 10, 9, 0), at(3, 9, 9, 0), at(3, 10, 18, 0)}, at(3, 10, 22, 0), 2, 2},
+		// Clocks go forward on 29 March; that day is 23 hours long
+		{"across DST", []time.Time{at(3, 28, 23, 30), at(3, 29, 23, 30), at(3, 30, 0, 30)}, at(3, 30, 8, 0), 3, 3},
+		// 00:30 in London in summer is 23:30 UTC the day before; it counts for the London day
+		{"UTC timestamps", []time.Time{at(4, 9, 0, 30).UTC(), at(4, 10, 8, 0).UTC()}, at(4, 10, 9, 0), 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := CalculateStreaksFrom(tt.days, tt.now)
+			if current != tt.current || longest != tt.long {
+				t.Errorf("got current %d, longest %d; want %d, %d", current, longest, tt.current, tt.long)
+			}
+		})
+	}
+}
+
+func TestCalculateStreaksWithFreezes(t *testing.T) {
+	at := func(d int) time.Time {
+		return time.Date(2026, 3, d, 20, 0, 0, 0, time.UTC) // 2 March 2026 is a Monday
+	}
+	days := func(ds ...int) []time.Time {
+		var out []time.Time
+		for _, d := range ds {
+			out = append(out, at(d))
+		}
+		return out
+	}
+	oneAWeek := StreakFreezes{PerWeek: 1, WeekStart: time.Monday}
+
+	tests := []struct {
+		name          string
+		days          []time.Time
+		now           time.Time
+		freezes       StreakFreezes
+		current, long int
+	}{
+		{"off by default", days(5, 6, 8, 9), at(9), StreakFreezes{}, 2, 2},
+		{"one missed day is bridged", days(5, 6, 8, 9), at(9), oneAWeek, 4, 4},
+		{"two missed days in a row still break it", days(4, 5, 8, 9), at(9), oneAWeek, 2, 2},
+		{"the budget runs out within a week", days(2, 4, 6), at(6), oneAWeek, 2, 2},
+		{"each week has its own freeze", days(6, 8, 10), at(10), oneAWeek, 3, 3},
+		{"a frozen yesterday keeps the streak open today", days(7, 8), at(10), oneAWeek, 2, 2},
+		{"two days since the last session is too many", days(7, 8), at(11), oneAWeek, 0, 2},
+		{"a bigger budget bridges more", days(2, 4, 6), at(6), StreakFreezes{PerWeek: 2, WeekStart: time.Monday}, 3, 3},
+		// Sunday 8 and Tuesday 10 share a week only when weeks start on Sunday
+		{"weeks follow the week start", days(7, 9, 11), at(11), StreakFreezes{PerWeek: 1, WeekStart: time.Sunday}, 2, 2},
+		{"weeks follow the week start (Monday)", days(7, 9, 11), at(11), oneAWeek, 3, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := CalculateStreaksWithFreezes(tt.days, tt.now, tt.freezes)
+			if current != tt.current || longest != tt.long {
+				t.Errorf("got current %d, longest %d; want %d, %d", current, longest, tt.current, tt.long)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package db
 
 import (
-	"context"
+	"sort"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -10,22 +10,87 @@ import (
 )
 
 type Subject struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	Name      string             `bson:"name"`
-	Icon      string             `bson:"icon"`
-	CreatedAt time.Time          `bson:"created_at"`
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	Name            string             `bson:"name"`
+	Icon            string             `bson:"icon"`
+	DefaultDuration int                `bson:"default_duration,omitempty"` // In minutes, 0 = use the configured work duration
+	Order           int                `bson:"order,omitempty"`            // List position from 1; 0 = not yet ordered
+	Archived        bool               `bson:"archived,omitempty"`         // Hidden from selection, kept for stats and history
+	StrictQuotes    bool               `bson:"strict_quotes,omitempty"`    // Rotate only quotes tagged with this subject
+	CreatedAt       time.Time          `bson:"created_at"`
 }
 
-func SubjectsCollection() *mongo.Collection {
-	return Database.Collection("subjects")
+// SessionMinutes returns the subject's default duration, or fallback if it has none
+func (s Subject) SessionMinutes(fallback int) int {
+	if s.DefaultDuration <= 0 {
+		return fallback
+	}
+	return s.DefaultDuration
+}
+
+// sortSubjects puts explicitly ordered subjects first by position, then the
+// rest (new or pre-ordering subjects) by creation time
+func sortSubjects(subjects []Subject) {
+	sort.SliceStable(subjects, func(i, j int) bool {
+		a, b := subjects[i], subjects[j]
+		if (a.Order == 0) != (b.Order == 0) {
+			return a.Order != 0
+		}
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+}
+
+// ReorderSubject moves the subject with id to position newPos (0-based) in
+// the list returned by GetAllSubjects, renumbering every subject
+func ReorderSubject(id primitive.ObjectID, newPos int) error {
+	subjects, err := GetAllSubjects()
+	if err != nil {
+		return err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(subjects))
+	for _, s := range subjects {
+		if s.ID != id {
+			ids = append(ids, s.ID)
+		}
+	}
+	if len(ids) == len(subjects) {
+		return mongo.ErrNoDocuments
+	}
+	newPos = max(0, min(newPos, len(ids)))
+	ids = append(ids[:newPos], append([]primitive.ObjectID{id}, ids[newPos:]...)...)
+
+	return SetSubjectOrder(ids)
+}
+
+func SubjectsCollection() (*mongo.Collection, error) {
+	return collection("subjects")
 }
 
-// GetAllSubjects returns all subjects
+// GetAllSubjects returns all subjects that are not archived
 func GetAllSubjects() ([]Subject, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return GetSubjects(false)
+}
+
+// GetSubjects returns subjects in list order, optionally including archived ones
+func (MongoStore) GetSubjects(includeArchived bool) ([]Subject, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	cursor, err := SubjectsCollection().Find(ctx, bson.M{})
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{}
+	if !includeArchived {
+		filter["archived"] = bson.M{"$ne": true}
+	}
+
+	cursor, err := coll.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -35,34 +100,92 @@ func GetAllSubjects() ([]Subject, error) {
 	if err := cursor.All(ctx, &subjects); err != nil {
 		return nil, err
 	}
+	sortSubjects(subjects)
 	return subjects, nil
 }
 
+// SetSubjectOrder numbers subjects in the order of ids, starting from 1
+func (MongoStore) SetSubjectOrder(ids []primitive.ObjectID) error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, len(ids))
+	for i, id := range ids {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{"order": i + 1}})
+	}
+	_, err = coll.BulkWrite(ctx, models)
+	return err
+}
+
 // GetSubjectByID returns a subject by its ID
-func GetSubjectByID(id primitive.ObjectID) (*Subject, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) GetSubjectByID(id primitive.ObjectID) (*Subject, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	var subject Subject
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	err = coll.FindOne(ctx, bson.M{"_id": id}).Decode(&subject)
+	if err != nil {
+		return nil, err
+	}
+	return &subject, nil
+}
+
+// GetSubjectByName returns a subject by its name
+func (MongoStore) GetSubjectByName(name string) (*Subject, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
 	var subject Subject
-	err := SubjectsCollection().FindOne(ctx, bson.M{"_id": id}).Decode(&subject)
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	err = coll.FindOne(ctx, bson.M{"name": name}).Decode(&subject)
 	if err != nil {
 		return nil, err
 	}
 	return &subject, nil
 }
 
-// AddSubject creates a new subject
+// AddSubject creates a new subject with the standard session length
 func AddSubject(name, icon string) (*Subject, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return AddSubjectWithDuration(name, icon, 0)
+}
+
+// AddSubjectWithDuration creates a new subject with a default session length in minutes
+func (MongoStore) AddSubjectWithDuration(name, icon string, duration int) (*Subject, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
 	subject := Subject{
-		Name:      name,
-		Icon:      icon,
-		CreatedAt: time.Now(),
+		Name:            name,
+		Icon:            icon,
+		DefaultDuration: duration,
+		CreatedAt:       time.Now(),
+	}
+
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := SubjectsCollection().InsertOne(ctx, subject)
+	result, err := coll.InsertOne(ctx, subject)
 	if err != nil {
 		return nil, err
 	}
@@ -72,13 +195,18 @@ func AddSubject(name, icon string) (*Subject, error) {
 }
 
 // AddSubjectIfNotExists creates a subject only if one with the same name doesn't exist
-func AddSubjectIfNotExists(name, icon string) (*Subject, bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) AddSubjectIfNotExists(name, icon string) (*Subject, bool, error) {
+	ctx, cancel := queryContext()
 	defer cancel()
 
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return nil, false, err
+	}
+
 	// Check if subject already exists
 	var existing Subject
-	err := SubjectsCollection().FindOne(ctx, bson.M{"name": name}).Decode(&existing)
+	err = coll.FindOne(ctx, bson.M{"name": name}).Decode(&existing)
 	if err == nil {
 		// Already exists
 		return &existing, false, nil
@@ -94,7 +222,7 @@ func AddSubjectIfNotExists(name, icon string) (*Subject, bool, error) {
 		CreatedAt: time.Now(),
 	}
 
-	result, err := SubjectsCollection().InsertOne(ctx, subject)
+	result, err := coll.InsertOne(ctx, subject)
 	if err != nil {
 		return nil, false, err
 	}
@@ -103,11 +231,44 @@ func AddSubjectIfNotExists(name, icon string) (*Subject, bool, error) {
 	return &subject, true, nil
 }
 
+// SetSubjectArchived archives or restores a subject
+func (MongoStore) SetSubjectArchived(id primitive.ObjectID, archived bool) error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"archived": archived}})
+	return err
+}
+
+// SetSubjectStrictQuotes sets whether a subject's sessions skip general quotes
+func (MongoStore) SetSubjectStrictQuotes(id primitive.ObjectID, strict bool) error {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"strict_quotes": strict}})
+	return err
+}
+
 // DeleteSubject removes a subject by ID
-func DeleteSubject(id primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (MongoStore) DeleteSubject(id primitive.ObjectID) error {
+	ctx, cancel := queryContext()
 	defer cancel()
 
-	_, err := SubjectsCollection().DeleteOne(ctx, bson.M{"_id": id})
+	coll, err := SubjectsCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.DeleteOne(ctx, bson.M{"_id": id})
 	return err
 }
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ParseTags splits a comma-separated tag list as typed by the user
+func ParseTags(input string) []string {
+	return NormalizeTags(strings.Split(input, ","))
+}
+
+// NormalizeTags trims and lowercases tags, collapsing inner whitespace and
+// dropping blanks and repeats. It returns nil when no tags remain.
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	return out
+}
+
+// GetMinutesByTag returns total completed minutes per tag. A session with
+// several tags counts towards each of them.
+func (MongoStore) GetMinutesByTag() (map[string]int, error) {
+	ctx, cancel := queryContext()
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "status", Value: StatusCompleted}}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$tags"},
+			{Key: "minutes", Value: bson.D{{Key: "$sum", Value: "$duration"}}},
+		}}},
+	}
+
+	coll, err := SessionsCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Tag     string `bson:"_id"`
+		Minutes int    `bson:"minutes"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]int)
+	for _, row := range rows {
+		results[row.Tag] = row.Minutes
+	}
+	return results, nil
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxQuoteLength is the longest quote text accepted, in characters. It
+// matches the character limit of the add-quote form.
+const MaxQuoteLength = 500
+
+// ValidationError reports input that was rejected before reaching storage
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// NormalizeQuoteText trims the text, collapses runs of spaces and tabs,
+// keeps single line breaks and squeezes repeated blank lines
+func NormalizeQuoteText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// unknownSources are placeholder attributions treated as no source at all
+var unknownSources = map[string]bool{
+	"unknown":   true,
+	"anonymous": true,
+	"anon":      true,
+	"n/a":       true,
+	"-":         true,
+	"—":         true,
+	"?":         true,
+}
+
+// NormalizeQuoteSource trims the source and collapses whitespace. Blank and
+// placeholder attributions such as "Anonymous" or "unknown" become "" so
+// they are stored the same way as quotes imported without a source.
+func NormalizeQuoteSource(source string) string {
+	source = strings.Join(strings.Fields(source), " ")
+	if unknownSources[strings.ToLower(strings.TrimSuffix(source, "."))] {
+		return ""
+	}
+	return source
+}
+
+// ValidateQuote normalizes quote text and source, returning a
+// *ValidationError if the text is empty or too long
+func ValidateQuote(text, source string) (string, string, error) {
+	text = NormalizeQuoteText(text)
+	source = NormalizeQuoteSource(source)
+
+	if text == "" {
+		return "", "", &ValidationError{Field: "quote", Message: "cannot be empty"}
+	}
+	if n := utf8.RuneCountInString(text); n > MaxQuoteLength {
+		return "", "", &ValidationError{
+			Field:   "quote",
+			Message: fmt.Sprintf("is %d characters; the limit is %d", n, MaxQuoteLength),
+		}
+	}
+	return text, source, nil
+}
+
+// ValidatePoem normalizes a poem's fields the way quotes are: the texts
+// keep their line breaks, the source and line reference are trimmed. Both
+// texts and the source are required.
+func ValidatePoem(oldEnglish, modernEnglish, source, lineRef string) (string, string, string, string, error) {
+	oldEnglish = NormalizeQuoteText(oldEnglish)
+	modernEnglish = NormalizeQuoteText(modernEnglish)
+	source = strings.Join(strings.Fields(source), " ")
+	lineRef = strings.Join(strings.Fields(lineRef), " ")
+
+	switch {
+	case oldEnglish == "":
+		return "", "", "", "", &ValidationError{Field: "Old English text", Message: "cannot be empty"}
+	case modernEnglish == "":
+		return "", "", "", "", &ValidationError{Field: "modern English text", Message: "cannot be empty"}
+	case source == "":
+		return "", "", "", "", &ValidationError{Field: "source", Message: "cannot be empty"}
+	}
+	return oldEnglish, modernEnglish, source, lineRef, nil
+}
+
+// MaxManualMinutes is the longest session that can be logged after the fact
+const MaxManualMinutes = 600
+
+// ValidateManualSession checks a session logged after the fact: the
+// duration must be positive and the session must have ended by now
+func ValidateManualSession(minutes int, startedAt, now time.Time) error {
+	if minutes <= 0 {
+		return &ValidationError{Field: "duration", Message: "must be at least 1 minute"}
+	}
+	if minutes > MaxManualMinutes {
+		return &ValidationError{Field: "duration", Message: fmt.Sprintf("cannot be more than %d minutes", MaxManualMinutes)}
+	}
+	if startedAt.After(now) {
+		return &ValidationError{Field: "start time", Message: "is in the future"}
+	}
+	if startedAt.Add(time.Duration(minutes) * time.Minute).After(now) {
+		return &ValidationError{Field: "session", Message: "would end in the future"}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateQuote(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantText string
+		wantErr  bool
+	}{
+		{"trims and collapses", "  Wyrd   oft\tnereð  ", "Wyrd oft nereð", false},
+		{"keeps line breaks", "line one \r\nline two", "line one\nline two", false},
+		{"squeezes blank lines", "a\n\n\n\nb", "a\n\nb", false},
+		{"empty", "   \n\t ", "", true},
+		{"at limit", strings.Repeat("þ", MaxQuoteLength), strings.Repeat("þ", MaxQuoteLength), false},
+		{"too long", strings.Repeat("a", MaxQuoteLength+1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, _, err := ValidateQuote(tt.text, "")
+			var verr *ValidationError
+			if tt.wantErr {
+				if !errors.As(err, &verr) {
+					t.Fatalf("got error %v, want *ValidationError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text != tt.wantText {
+				t.Errorf("text = %q, want %q", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestValidatePoem(t *testing.T) {
+	oe, modern, source, ref, err := ValidatePoem("  Hwæt!  We Gardena \r\nin geardagum ", "Listen!", " The  Wanderer ", " 1-3 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if oe != "Hwæt! We Gardena\nin geardagum" || modern != "Listen!" || source != "The Wanderer" || ref != "1-3" {
+		t.Errorf("got %q, %q, %q, %q", oe, modern, source, ref)
+	}
+
+	for _, missing := range [][4]string{
+		{"", "Listen!", "Beowulf", ""},
+		{"Hwæt!", " ", "Beowulf", ""},
+		{"Hwæt!", "Listen!", "", "1-3"},
+	} {
+		_, _, _, _, err := ValidatePoem(missing[0], missing[1], missing[2], missing[3])
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("ValidatePoem%q: got error %v, want *ValidationError", missing, err)
+		}
+	}
+}
+
+func TestNormalizeQuoteSource(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"  Kent   Beck ", "Kent Beck"},
+		{"Unknown", ""},
+		{" anonymous. ", ""},
+		{"ANON", ""},
+		{"n/a", ""},
+		{"Unknown Soldier", "Unknown Soldier"},
+		{"Beowulf, lines 572-573", "Beowulf, lines 572-573"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeQuoteSource(tt.in); got != tt.want {
+			t.Errorf("NormalizeQuoteSource(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidateManualSession(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		minutes   int
+		startedAt time.Time
+		wantErr   bool
+	}{
+		{"ended an hour ago", 25, now.Add(-85 * time.Minute), false},
+		{"ends right now", 30, now.Add(-30 * time.Minute), false},
+		{"zero minutes", 0, now.Add(-time.Hour), true},
+		{"too long", MaxManualMinutes + 1, now.AddDate(0, 0, -1), true},
+		{"starts in the future", 25, now.Add(time.Minute), true},
+		{"still running", 60, now.Add(-30 * time.Minute), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateManualSession(tt.minutes, tt.startedAt, now)
+			var invalid *ValidationError
+			if tt.wantErr != errors.As(err, &invalid) {
+				t.Errorf("got %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// ParseWeekStart reads "monday" or "sunday", ignoring case and spaces
+func ParseWeekStart(s string) (time.Weekday, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "monday":
+		return time.Monday, true
+	case "sunday":
+		return time.Sunday, true
+	}
+	return time.Monday, false
+}
+
+// WeekStartOverride returns the week start forced by BEOT_WEEK_START, if any
+func WeekStartOverride() (time.Weekday, bool) {
+	return ParseWeekStart(os.Getenv("BEOT_WEEK_START"))
+}
+
+// GetWeekStart returns the first day of the week: BEOT_WEEK_START wins,
+// then the saved setting, then Monday
+func GetWeekStart() (time.Weekday, error) {
+	if day, ok := WeekStartOverride(); ok {
+		return day, nil
+	}
+	value, err := GetSetting(SettingWeekStart)
+	day, _ := ParseWeekStart(value)
+	return day, err
+}
+
+// SetWeekStart stores the first day of the week
+func SetWeekStart(day time.Weekday) error {
+	return SetSetting(SettingWeekStart, strings.ToLower(day.String()))
+}
+
+// StartOfWeek returns local midnight on the most recent start day at or
+// before t. Going through time.Date keeps it midnight across DST changes,
+// where subtracting a fixed 24h per day would not.
+func StartOfWeek(t time.Time, start time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(start) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// startOfDay returns local midnight at the start of t's calendar day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// GetTodayMinutes sums completed focus minutes since local midnight
+func GetTodayMinutes() (int, error) {
+	return GetMinutesSince(startOfDay(time.Now()))
+}
+
+// GetCompletedCountToday counts the focus blocks completed since local
+// midnight, by start time like GetTodayMinutes
+func GetCompletedCountToday() (int, error) {
+	return CountCompletedSince(startOfDay(time.Now()))
+}
+
+// GetMinutesForDay sums completed focus minutes of sessions started on
+// day's local calendar date
+func GetMinutesForDay(day time.Time) (int, error) {
+	start := startOfDay(day)
+	sinceStart, err := GetMinutesSince(start)
+	if err != nil {
+		return 0, err
+	}
+	sinceEnd, err := GetMinutesSince(start.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, err
+	}
+	return sinceStart - sinceEnd, nil
+}
+
+// GetWeekMinutes sums completed focus minutes since the start of this local week
+func GetWeekMinutes() (int, error) {
+	start, err := GetWeekStart()
+	if err != nil {
+		return 0, err
+	}
+	return GetMinutesSince(StartOfWeek(time.Now(), start))
+}
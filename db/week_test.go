@@ -0,0 +1,140 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+	_ "time/tzdata" // Named zones for the DST cases, regardless of the host
+)
+
+func TestStartOfWeek(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(loc *time.Location, y int, m time.Month, d, h int) time.Time {
+		return time.Date(y, m, d, h, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name  string
+		t     time.Time
+		start time.Weekday
+		want  time.Time
+	}{
+		{"Monday is its own start", at(time.UTC, 2026, 3, 2, 9), time.Monday, at(time.UTC, 2026, 3, 2, 0)},
+		{"Sunday belongs to the week before", at(time.UTC, 2026, 3, 8, 23), time.Monday, at(time.UTC, 2026, 3, 2, 0)},
+		{"Sunday start on a Sunday", at(time.UTC, 2026, 3, 8, 23), time.Sunday, at(time.UTC, 2026, 3, 8, 0)},
+		{"Sunday start on a Monday", at(time.UTC, 2026, 3, 9, 0), time.Sunday, at(time.UTC, 2026, 3, 8, 0)},
+		// Clocks go forward on Sunday 29 March 2026 in London
+		{"week spanning spring forward", at(london, 2026, 3, 29, 12), time.Monday, at(london, 2026, 3, 23, 0)},
+		{"first week after spring forward", at(london, 2026, 4, 1, 8), time.Monday, at(london, 2026, 3, 30, 0)},
+		{"spring forward Sunday start", at(london, 2026, 3, 31, 8), time.Sunday, at(london, 2026, 3, 29, 0)},
+		// Clocks go back on Sunday 1 November 2026 in New York
+		{"fall back Sunday start", at(newYork, 2026, 11, 4, 18), time.Sunday, at(newYork, 2026, 11, 1, 0)},
+		{"week spanning fall back", at(newYork, 2026, 11, 1, 23), time.Monday, at(newYork, 2026, 10, 26, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StartOfWeek(tt.t, tt.start)
+			if !got.Equal(tt.want) {
+				t.Errorf("StartOfWeek(%v, %v) = %v, want %v", tt.t, tt.start, got, tt.want)
+			}
+			if h, m, _ := got.Clock(); h != 0 || m != 0 {
+				t.Errorf("StartOfWeek(%v) = %v, not local midnight", tt.t, got)
+			}
+		})
+	}
+}
+
+func TestGetWeekStart(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Use(store)
+	defer Use(MongoStore{})
+
+	t.Setenv("BEOT_WEEK_START", "")
+	if got, _ := GetWeekStart(); got != time.Monday {
+		t.Errorf("default GetWeekStart = %v, want Monday", got)
+	}
+
+	if err := SetWeekStart(time.Sunday); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetWeekStart(); got != time.Sunday {
+		t.Errorf("saved GetWeekStart = %v, want Sunday", got)
+	}
+
+	// The environment wins over the saved setting
+	t.Setenv("BEOT_WEEK_START", "Monday")
+	if got, _ := GetWeekStart(); got != time.Monday {
+		t.Errorf("GetWeekStart with BEOT_WEEK_START = %v, want Monday", got)
+	}
+}
+
+func TestGetMinutesForDay(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Use(store)
+	defer Use(MongoStore{})
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	for _, s := range []struct {
+		at      time.Time
+		minutes int
+		status  SessionStatus
+	}{
+		{day.Add(-time.Minute), 25, StatusCompleted}, // The evening before
+		{day.Add(9 * time.Hour), 30, StatusCompleted},
+		{day.Add(23*time.Hour + 50*time.Minute), 15, StatusCompleted},
+		{day.Add(12 * time.Hour), 10, StatusAbandoned},
+		{day.AddDate(0, 0, 1), 45, StatusCompleted}, // Midnight belongs to the next day
+	} {
+		store.AddSessionIfNotExists(Session{SubjectName: "Go", Duration: s.minutes, Status: s.status, StartedAt: s.at, CompletedAt: s.at})
+	}
+
+	if got, err := GetMinutesForDay(day.Add(15 * time.Hour)); err != nil || got != 45 {
+		t.Errorf("GetMinutesForDay = %d, %v; want 45", got, err)
+	}
+	if got, _ := GetMinutesForDay(day.AddDate(0, 0, -2)); got != 0 {
+		t.Errorf("an empty day = %d, want 0", got)
+	}
+}
+
+func TestGetCompletedCountToday(t *testing.T) {
+	store, err := OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Use(store)
+	defer Use(MongoStore{})
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, s := range []struct {
+		at     time.Time
+		status SessionStatus
+	}{
+		{midnight.Add(-time.Minute), StatusCompleted}, // Yesterday
+		{midnight, StatusCompleted},
+		{now, StatusCompleted},
+		{now, StatusAbandoned},
+	} {
+		store.AddSessionIfNotExists(Session{SubjectName: "Go", Duration: 25, Status: s.status, StartedAt: s.at, CompletedAt: s.at})
+	}
+	// The first part of a block split by switching subject
+	store.AddSessionIfNotExists(Session{SubjectName: "Latin", Duration: 10, Status: StatusCompleted, StartedAt: now.Add(-time.Second), CompletedAt: now, Segment: true})
+
+	if got, err := GetCompletedCountToday(); err != nil || got != 2 {
+		t.Errorf("GetCompletedCountToday = %d, %v; want 2", got, err)
+	}
+}
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -24,19 +29,238 @@ func main() {
 		return
 	}
 
+	// Handle subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			os.Exit(runImport(os.Args[2:]))
+		case "stats":
+			os.Exit(runStats(os.Args[2:]))
+		case "backup":
+			os.Exit(runBackup(os.Args[2:]))
+		case "restore":
+			os.Exit(runRestore(os.Args[2:]))
+		}
+	}
+
+	subjectName := flag.String("subject", "", "start a session for this subject, skipping the menu")
+	minutes := flag.Int("minutes", 0, "session length in minutes (default: the subject's default)")
+	noSplash := flag.Bool("no-splash", false, "skip the intro vow and open straight to the menu")
+	profile := flag.String("profile", "", "connect using this profile from the config file (overrides BEOT_PROFILE)")
+	flag.Parse()
+
+	if *profile != "" {
+		os.Setenv("BEOT_PROFILE", *profile)
+	}
+
 	// Set version for UI
 	ui.Version = Version
 
-	// Connect to MongoDB
-	if err := db.Connect(); err != nil {
-		fmt.Printf("Failed to connect to database: %v\n", err)
-		os.Exit(1)
+	// Pick a readable palette for the terminal background
+	ui.DetectAndApplyBackground()
+
+	os.Exit(runTUI(*subjectName, *minutes, *noSplash))
+}
+
+// runTUI opens storage and runs the interactive app, returning the exit code.
+// Keeping this separate from main ensures the deferred disconnect runs before exit.
+func runTUI(subjectName string, minutes int, noSplash bool) int {
+	if err := ui.ApplyKeyOverrides(db.KeyOverrides()); err != nil {
+		p := tea.NewProgram(ui.NewErrorModel("Invalid key bindings in the config file", err), tea.WithAltScreen())
+		if _, runErr := p.Run(); runErr != nil {
+			fmt.Printf("Invalid key bindings in the config file: %v\n", err)
+		}
+		return 1
+	}
+
+	// Connect to the configured storage backend
+	if err := db.Open(); err != nil {
+		// Show a friendly full-screen error instead of failing on first use
+		title := "Failed to connect to database"
+		if errors.Is(err, db.ErrInvalidMongoURI) {
+			title = "Invalid MongoDB connection string"
+		}
+		p := tea.NewProgram(ui.NewErrorModel(title, err), tea.WithAltScreen())
+		if _, runErr := p.Run(); runErr != nil {
+			fmt.Printf("Failed to connect to database: %v\n", err)
+		}
+		return 1
+	}
+	defer db.Close()
+	if db.MigrationErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", db.MigrationErr)
+	}
+
+	app := ui.NewAppModel()
+	if subjectName == "" && !noSplash {
+		if skip, _ := db.GetBoolSetting(db.SettingSkipSplash); !skip {
+			app.ShowSplash()
+		}
+	}
+	if subjectName != "" {
+		subject, err := db.GetSubjectByName(subjectName)
+		if err != nil {
+			fmt.Printf("Subject %q not found: %v\n", subjectName, err)
+			return 1
+		}
+		if minutes <= 0 {
+			durations, _ := db.GetDurations()
+			minutes = subject.SessionMinutes(durations.Work)
+		}
+		app = ui.NewAppModelWithSession(*subject, minutes)
 	}
-	defer db.Disconnect()
 
-	p := tea.NewProgram(ui.NewAppModel(), tea.WithAltScreen())
+	p := tea.NewProgram(app, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error: %v", err)
-		os.Exit(1)
+		fmt.Printf("Error: %v\n", err)
+		return 1
 	}
+	return 0
+}
+
+// runImport loads quotes from a JSON or CSV file: beot import <file>
+func runImport(args []string) int {
+	if len(args) != 1 {
+		fmt.Println("Usage: beot import <quotes.json|quotes.csv>")
+		return 2
+	}
+	path := args[0]
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("Failed to open %s: %v\n", path, err)
+		return 1
+	}
+	defer f.Close()
+
+	if err := db.Open(); err != nil {
+		fmt.Printf("Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	added, skipped, err := db.ImportQuotes(f, format)
+	if err != nil {
+		fmt.Printf("Import failed after adding %d quotes: %v\n", added, err)
+		return 1
+	}
+	fmt.Printf("Imported %d new quotes (%d skipped)\n", added, skipped)
+	return 0
+}
+
+// runBackup writes all subjects, quotes, poems and sessions to a JSON file: beot backup <file>
+func runBackup(args []string) int {
+	if len(args) != 1 {
+		fmt.Println("Usage: beot backup <file.json>")
+		return 2
+	}
+	path := args[0]
+
+	if err := db.Open(); err != nil {
+		fmt.Printf("Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("Failed to create %s: %v\n", path, err)
+		return 1
+	}
+	if err := db.WriteBackup(f); err != nil {
+		f.Close()
+		fmt.Printf("Backup failed: %v\n", err)
+		return 1
+	}
+	if err := f.Close(); err != nil {
+		fmt.Printf("Failed to write %s: %v\n", path, err)
+		return 1
+	}
+	fmt.Printf("Backed up to %s\n", path)
+	return 0
+}
+
+// runRestore adds everything missing from a backup file: beot restore <file>
+func runRestore(args []string) int {
+	if len(args) != 1 {
+		fmt.Println("Usage: beot restore <file.json>")
+		return 2
+	}
+	path := args[0]
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("Failed to open %s: %v\n", path, err)
+		return 1
+	}
+	defer f.Close()
+
+	if err := db.Open(); err != nil {
+		fmt.Printf("Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	summary, err := db.RestoreBackup(f)
+	for _, row := range []struct {
+		name  string
+		count db.RestoreCount
+	}{
+		{"Subjects", summary.Subjects},
+		{"Quotes", summary.Quotes},
+		{"Poems", summary.Poems},
+		{"Sessions", summary.Sessions},
+	} {
+		fmt.Printf("%-9s %d added, %d skipped\n", row.name+":", row.count.Added, row.count.Skipped)
+	}
+	if err != nil {
+		fmt.Printf("Restore stopped early: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runStats prints session statistics without starting the TUI: beot stats [--json]
+func runStats(args []string) int {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print stats as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := db.Open(); err != nil {
+		fmt.Printf("Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	stats, err := db.GetSessionStats()
+	if err != nil {
+		fmt.Printf("Failed to load stats: %v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		out, err := json.MarshalIndent(map[string]int{
+			"completed":      stats.CompletedSessions,
+			"abandoned":      stats.AbandonedSessions,
+			"total_minutes":  stats.TotalMinutes,
+			"current_streak": stats.CurrentStreak,
+			"longest_streak": stats.LongestStreak,
+		}, "", "  ")
+		if err != nil {
+			fmt.Printf("Failed to encode stats: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(out))
+		return 0
+	}
+
+	fmt.Printf("Completed:      %d\n", stats.CompletedSessions)
+	fmt.Printf("Abandoned:      %d\n", stats.AbandonedSessions)
+	fmt.Printf("Total minutes:  %d\n", stats.TotalMinutes)
+	fmt.Printf("Current streak: %d days\n", stats.CurrentStreak)
+	fmt.Printf("Longest streak: %d days\n", stats.LongestStreak)
+	return 0
 }
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Enabled controls whether desktop notifications are sent. It defaults to
+// true; Settings → Desktop notifications changes it, and BEOT_NOTIFICATIONS
+// (on or off) always wins.
+var Enabled = true
+
+// forced records that BEOT_NOTIFICATIONS has decided
+var forced bool
+
+func init() {
+	switch strings.ToLower(os.Getenv("BEOT_NOTIFICATIONS")) {
+	case "off", "false", "0", "no":
+		Enabled, forced = false, true
+	case "on", "true", "1", "yes":
+		Enabled, forced = true, true
+	}
+}
+
+// Forced reports whether BEOT_NOTIFICATIONS overrides the saved setting
+func Forced() bool {
+	return forced
+}
+
+// SetEnabled applies the saved setting unless the environment has already decided
+func SetEnabled(on bool) {
+	if !forced {
+		Enabled = on
+	}
+}
+
+// Send shows an OS desktop notification. It is best-effort: any failure
+// (missing tool, unsupported OS) is returned but never panics.
+func Send(title, message string) error {
+	if !Enabled {
+		return nil
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("notify-send", "--app-name=Beot", title, message)
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		script := fmt.Sprintf(`[void][Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime]
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode('%s')) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode('%s')) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('Beot').Show($toast)`,
+			psEscape(title), psEscape(message))
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	default:
+		return fmt.Errorf("desktop notifications not supported on %s", runtime.GOOS)
+	}
+
+	return cmd.Run()
+}
+
+// SessionComplete notifies that a focus session on the given subject finished
+func SessionComplete(subjectName string) {
+	message := "Your honour remains unbroken."
+	if subjectName != "" {
+		message = fmt.Sprintf("%s — your honour remains unbroken.", subjectName)
+	}
+	// Best-effort: ignore errors so a missing notifier never affects the timer
+	_ = Send("Vow kept", message)
+}
+
+// BreakComplete notifies that a break has ended
+func BreakComplete() {
+	_ = Send("Break over", "Take up your vow again.")
+}
+
+// psEscape escapes a string for use inside a single-quoted PowerShell literal
+func psEscape(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
@@ -2,11 +2,14 @@ package ui
 
 import (
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"Beot/alert"
 	"Beot/db"
+	"Beot/notify"
 )
 
 // View represents which screen is active
@@ -18,17 +21,67 @@ const (
 	TimerViewState
 	StatsViewState
 	QuotesViewState
+	HistoryViewState
+	SettingsViewState
+	RecoveryViewState
+	MilestoneViewState
+	SplashViewState
+	IntentionViewState
+	ReflectionViewState
+	ErrorViewState
+	ManualEntryViewState
+	PoemsViewState
 )
 
 // AppModel is the main application container
 type AppModel struct {
-	currentView   View
-	menu          MenuModel
-	subjectSelect SubjectSelectModel
-	timer         TimerModel
-	quotes        QuotesModel
-	stats         *db.SessionStats
-	statsErr      error
+	currentView     View
+	menu            MenuModel
+	subjectSelect   SubjectSelectModel
+	intention       IntentionModel
+	reflection      ReflectionModel
+	errorView       ErrorModel // Shown when the database connection is lost
+	timer           TimerModel
+	quotes          QuotesModel
+	history         HistoryModel
+	manualEntry     ManualEntryModel
+	poems           PoemsModel
+	settings        SettingsModel
+	stats           *db.SessionStats
+	statsRange      statsRange       // Period the stats screen's session counts cover
+	rangeStats      *db.SessionStats // Counts for statsRange; unused for all time
+	statsErr        error
+	minutesBySubj   map[string]int
+	ratingBySubj    map[string]float64
+	minutesByTag    map[string]int
+	weekMinutes     int         // Focus minutes this week
+	weeklyGoal      int         // Weekly target in minutes, 0 = none
+	longest         *db.Session // Longest completed focus block, for the stats view
+	showHabits      bool        // Stats view shows the time-of-day breakdown
+	habits          HabitsLoadedMsg
+	showCalendar    bool // Stats view shows the month calendar
+	calendar        CalendarModel
+	favoritesOnly   bool
+	minimalTimer    bool
+	skipSplash      bool   // Intro vow is turned off in settings
+	nightAfter      string // Night mode start as "HH:MM", "" = off
+	nightForced     bool   // ctrl+n has overridden the hour until the setting changes
+	alertMode       alert.Mode
+	durations       db.Durations
+	quoteMax        int  // Characters of a quote the timer shows, 0 = no limit
+	showHelp        bool // Key binding overlay is open over the current view
+	width, height   int  // Terminal size, handed to the timer for deep focus
+	wyrdStatus      string
+	wyrdErr         error
+	exportStatus    string // Where the markdown review went, or progress
+	exportErr       error
+	recovered       *db.ActiveSession // Unfinished session offered for resumption
+	milestone       int               // Lifetime hours just crossed, for the celebration view
+	record          int               // Minutes of a just-set longest block, for the celebration view
+	milestoneFrom   string            // Subject of the session that crossed it
+	afterMilestone  View              // Where a key press leaves the celebration view
+	afterReflection View              // Where saving or skipping the reflection leads
+	afterComplete   View              // Where leaving the completed timer screen leads
 }
 
 // NewAppModel creates the application
@@ -36,48 +89,569 @@ func NewAppModel() AppModel {
 	return AppModel{
 		currentView: MenuViewState,
 		menu:        NewMenuModel(),
+		alertMode:   alert.Resolve(""),
+		durations:   db.DefaultDurations(),
 	}
 }
 
+// NewAppModelWithSession creates the application already running a timer
+// for the given subject, skipping the menu. minutes <= 0 uses the subject default.
+func NewAppModelWithSession(subject db.Subject, minutes int) AppModel {
+	m := NewAppModel()
+	// The timer starts before Init restores the settings, so read the ones
+	// that shape it now. A read error leaves the defaults; Init's load
+	// reports it.
+	m.durations, _ = db.GetDurations()
+	if minutes <= 0 {
+		minutes = subject.SessionMinutes(m.durations.Work)
+	}
+	mode, _ := db.GetSetting(db.SettingDisplayMode)
+	m.menu.SetDisplayMode(ParseDisplayMode(mode))
+	m.favoritesOnly, _ = db.GetBoolSetting(db.SettingFavoritesOnly)
+	m.timer = NewTimerModelWithOptions(minutes, subject.ID.Hex(), subject.Name, TimerOptions{
+		DisplayMode:   m.menu.GetDisplayMode(),
+		FavoritesOnly: m.favoritesOnly,
+		StrictQuotes:  subject.StrictQuotes,
+		Alert:         m.alertMode,
+		Durations:     m.durations,
+	})
+	m.currentView = TimerViewState
+	return m
+}
+
+// timerBehind reports whether leaving for next, and any celebration it
+// leads to, returns to the timer, so a break there should keep counting down
+func (m AppModel) timerBehind(next View) bool {
+	if next == MilestoneViewState {
+		next = m.afterMilestone
+	}
+	return next == TimerViewState
+}
+
+// ShowSplash opens the app on the intro vow instead of the menu
+func (m *AppModel) ShowSplash() {
+	m.currentView = SplashViewState
+}
+
 func (m AppModel) Init() tea.Cmd {
-	// Load initial streak for menu display
-	return func() tea.Msg {
-		stats, _ := db.GetSessionStats()
-		return StatsLoadedMsg{Stats: stats}
+	var timerCmd tea.Cmd
+	if m.currentView == TimerViewState {
+		timerCmd = m.timer.Init()
 	}
+
+	return tea.Batch(
+		timerCmd,
+		// Load initial streak for menu display
+		loadStatsCmd(),
+		// Offer to resume a session interrupted by a crash
+		loadActiveSessionCmd(),
+		// Check the hour for night mode
+		nightTickCmd(),
+		loadQuoteOfTheDayCmd(),
+		// Restore saved preferences
+		func() tea.Msg {
+			mode, err := db.GetSetting(db.SettingDisplayMode)
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			favoritesOnly, err := db.GetBoolSetting(db.SettingFavoritesOnly)
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			alertMode, err := db.GetSetting(db.SettingAlert)
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			minimal, err := db.GetBoolSetting(db.SettingMinimalTimer)
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			skipSplash, err := db.GetBoolSetting(db.SettingSkipSplash)
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			nightAfter, err := db.GetSetting(db.SettingNightMode)
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			quoteMax, err := db.GetQuoteMaxChars()
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			reducedMotion, err := db.GetBoolSetting(db.SettingReducedMotion)
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			notifyOff, err := db.GetBoolSetting(db.SettingNotifyOff)
+			if err != nil {
+				return SettingsLoadedMsg{Err: err}
+			}
+			durations, err := db.GetDurations()
+			return SettingsLoadedMsg{
+				DisplayMode:   ParseDisplayMode(mode),
+				FavoritesOnly: favoritesOnly,
+				Alert:         alert.Resolve(alertMode),
+				Durations:     durations,
+				MinimalTimer:  minimal,
+				SkipSplash:    skipSplash,
+				NightAfter:    nightAfter,
+				QuoteMax:      quoteMax,
+				ReducedMotion: reducedMotion,
+				Notifications: !notifyOff,
+				Err:           err,
+			}
+		},
+	)
+}
+
+// SettingsLoadedMsg carries persisted user preferences
+type SettingsLoadedMsg struct {
+	DisplayMode   DisplayMode
+	FavoritesOnly bool
+	Alert         alert.Mode
+	Durations     db.Durations
+	MinimalTimer  bool
+	SkipSplash    bool
+	NightAfter    string
+	QuoteMax      int
+	ReducedMotion bool
+	Notifications bool // Desktop notifications, on unless turned off in Settings
+	Err           error
+}
+
+// nightTickMsg re-checks the hour for night mode
+type nightTickMsg struct{}
+
+// nightTickCmd checks for night mode every minute
+func nightTickCmd() tea.Cmd {
+	return tea.Tick(time.Minute, func(time.Time) tea.Msg {
+		return nightTickMsg{}
+	})
 }
 
 type StatsLoadedMsg struct {
-	Stats *db.SessionStats
-	Err   error
+	Stats            *db.SessionStats
+	MinutesBySubject map[string]int
+	MinutesByTag     map[string]int
+	RatingBySubject  map[string]float64 // Average reflection rating; unrated subjects are absent
+	WeekMinutes      int                // Completed minutes since the start of the week
+	TodayMinutes     int                // Completed minutes since local midnight
+	TodayCompleted   int                // Focus sessions completed since local midnight
+	YesterdayMinutes int                // Completed minutes on the previous calendar day
+	WeeklyGoal       int                // Target minutes per week, 0 = none
+	Longest          *db.Session
+	Err              error
+}
+
+// HabitsLoadedMsg carries the time-of-day and weekday breakdowns for the stats view
+type HabitsLoadedMsg struct {
+	ByHour    map[int]int
+	ByWeekday [7]int // Minutes, Monday first
+	WeekStart time.Weekday
+	Err       error
+}
+
+// loadHabitsCmd fetches completed sessions grouped by local hour and weekday
+func loadHabitsCmd() tea.Cmd {
+	return func() tea.Msg {
+		byHour, err := db.GetSessionsByHour()
+		if err != nil {
+			return HabitsLoadedMsg{Err: err}
+		}
+		byWeekday, err := db.GetMinutesByWeekday()
+		if err != nil {
+			return HabitsLoadedMsg{Err: err}
+		}
+		start, err := db.GetWeekStart()
+		return HabitsLoadedMsg{ByHour: byHour, ByWeekday: byWeekday, WeekStart: start, Err: err}
+	}
+}
+
+// SessionSavedMsg reports saving a finished or given-up block, with the
+// milestone or record it set
+type SessionSavedMsg struct {
+	Completed bool
+	OnBreak   bool        // A break follows the completed screen
+	Session   *db.Session // Nil if the save failed
+	Milestone int         // Lifetime hours just crossed, 0 if none
+	Record    int         // Minutes of a just-set longest block, 0 if none
+	Err       error
+}
+
+// saveErrText is the status line for a session that could not be saved
+func saveErrText(err error) string {
+	return "Could not save the session: " + err.Error()
+}
+
+// saveSessionCmd saves the block the timer reported, comparing it with the
+// lifetime total and longest block first to spot a milestone or record
+func saveSessionCmd(done TimerCompleteMsg) tea.Cmd {
+	return func() tea.Msg {
+		saved := SessionSavedMsg{Completed: done.Completed, OnBreak: done.OnBreak}
+		status := db.StatusCompleted
+		if !done.Completed {
+			status = db.StatusAbandoned
+		}
+
+		before := -1
+		if done.Completed {
+			if stats, err := db.GetSessionStats(); err == nil {
+				before = stats.TotalMinutes
+			}
+			// Only beating an earlier block counts; the first session is not a record
+			if longest, err := db.GetLongestSession(); err == nil && longest != nil && done.Duration > longest.Duration {
+				saved.Record = done.Duration
+			}
+		}
+
+		subjectID, _ := primitive.ObjectIDFromHex(done.SubjectID)
+		saved.Session, saved.Err = db.CreateSessionWithDetails(subjectID, done.SubjectName, done.Duration, status, done.StartedAt, done.Details)
+		// A failed save keeps the crash-recovery copy, the block's only record
+		if saved.Err == nil {
+			db.ClearActiveSession()
+		}
+
+		if hours, crossed := crossedMilestone(before, before+done.Duration); crossed && before >= 0 {
+			saved.Milestone = hours
+		}
+		return saved
+	}
+}
+
+// loadStatsCmd fetches session stats for the menu streak and stats view
+func loadStatsCmd() tea.Cmd {
+	return func() tea.Msg {
+		stats, err := db.GetSessionStats()
+		if err != nil {
+			return StatsLoadedMsg{Err: err}
+		}
+		minutes, err := db.GetMinutesBySubject()
+		if err != nil {
+			return StatsLoadedMsg{Err: err}
+		}
+		ratings := make(map[string]float64)
+		for name := range minutes {
+			if avg, err := db.GetAverageRating(name); err == nil && avg > 0 {
+				ratings[name] = avg
+			}
+		}
+		byTag, err := db.GetMinutesByTag()
+		if err != nil {
+			return StatsLoadedMsg{Err: err}
+		}
+		week, err := db.GetWeekMinutes()
+		if err != nil {
+			return StatsLoadedMsg{Err: err}
+		}
+		today, err := db.GetTodayMinutes()
+		if err != nil {
+			return StatsLoadedMsg{Err: err}
+		}
+		yesterday, err := db.GetMinutesForDay(time.Now().AddDate(0, 0, -1))
+		if err != nil {
+			return StatsLoadedMsg{Err: err}
+		}
+		completedToday, err := db.GetCompletedCountToday()
+		if err != nil {
+			return StatsLoadedMsg{Err: err}
+		}
+		goal, err := db.GetWeeklyGoal()
+		if err != nil {
+			return StatsLoadedMsg{Err: err}
+		}
+		longest, err := db.GetLongestSession()
+		return StatsLoadedMsg{
+			Stats:            stats,
+			MinutesBySubject: minutes,
+			RatingBySubject:  ratings,
+			MinutesByTag:     byTag,
+			WeekMinutes:      week,
+			TodayMinutes:     today,
+			TodayCompleted:   completedToday,
+			YesterdayMinutes: yesterday,
+			WeeklyGoal:       goal,
+			Longest:          longest,
+			Err:              err,
+		}
+	}
 }
 
 func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The help overlay captures keys while open; other messages still reach the view
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch key := keyMsg.String(); {
+		case m.showHelp && (key == "?" || key == "h" || key == "esc"):
+			m.showHelp = false
+			return m, nil
+		case m.showHelp && key == "ctrl+c":
+			return m, tea.Quit
+		case m.showHelp:
+			return m, nil
+		case (key == "?" || key == "h") && !m.isTyping():
+			m.showHelp = true
+			return m, nil
+		case key == "ctrl+n":
+			// Forced either way until the night mode setting changes
+			m.nightForced = true
+			m.setDimmed(!Dimmed)
+			return m, nil
+		}
+	}
+
+	// A lost connection replaces the current view, except under a running
+	// timer, which carries on from its in-memory content
+	if err := loadErr(msg); db.IsConnectionError(err) && m.currentView != TimerViewState && m.currentView != ErrorViewState {
+		m.errorView = NewRetryableErrorModel("Lost the connection to the database", err)
+		m.currentView = ErrorViewState
+		return m, nil
+	}
+
 	// Handle messages that affect navigation
 	switch msg := msg.(type) {
 
+	case tea.WindowSizeMsg:
+		// Only the timer lays itself out by size; keep it for timers made later
+		m.width, m.height = msg.Width, msg.Height
+		m.timer.width, m.timer.height = msg.Width, msg.Height
+		return m, nil
+
+	case RangeStatsLoadedMsg:
+		// Ignore a slow reply for a range that has since been cycled past
+		if msg.Range == m.statsRange {
+			m.rangeStats, m.statsErr = msg.Stats, msg.Err
+		}
+		return m, nil
+
+	case QuoteOfTheDayMsg:
+		// Without a quote the menu simply leaves the space empty
+		if msg.Err == nil {
+			m.menu.SetQuoteOfTheDay(msg.Quote)
+		}
+		return m, nil
+
+	case nightTickMsg:
+		m.checkNightMode()
+		return m, nightTickCmd()
+
+	case NightModeChangedMsg:
+		if msg.Err == nil {
+			m.nightAfter = msg.After
+			m.nightForced = false
+			m.checkNightMode()
+		}
+
+	case ReconnectedMsg:
+		if msg.Err == nil && m.currentView == ErrorViewState {
+			m.currentView = MenuViewState
+			return m, loadStatsCmd()
+		}
+
+	case HabitsLoadedMsg:
+		m.habits = msg
+		return m, nil
+
+	case CalendarLoadedMsg:
+		newCalendar, cmd := m.calendar.Update(msg)
+		m.calendar = newCalendar.(CalendarModel)
+		return m, cmd
+
 	case StatsLoadedMsg:
 		m.stats = msg.Stats
 		m.statsErr = msg.Err
+		m.minutesBySubj = msg.MinutesBySubject
+		m.ratingBySubj = msg.RatingBySubject
+		m.minutesByTag = msg.MinutesByTag
+		m.weekMinutes, m.weeklyGoal = msg.WeekMinutes, msg.WeeklyGoal
+		m.menu.SetWeeklyProgress(msg.WeekMinutes, msg.WeeklyGoal)
+		m.menu.SetDayComparison(msg.TodayMinutes, msg.YesterdayMinutes)
+		m.longest = msg.Longest
+		// Shown on the break that follows a saved block
+		m.timer.todayBlocks = msg.TodayCompleted
 		if msg.Stats != nil {
-			m.menu.SetStreak(msg.Stats.CurrentStreak)
+			m.menu.SetStats(*msg.Stats)
+			// The completed screen shows the progress the session just added
+			if m.currentView == TimerViewState && m.timer.completed() {
+				m.timer.summary = &completionSummary{streak: msg.Stats.CurrentStreak, todayMinutes: msg.TodayMinutes}
+			}
+		}
+		return m, nil
+
+	case CompletionAcknowledgedMsg:
+		m.currentView = m.afterComplete
+		return m, nil
+
+	case SettingsLoadedMsg:
+		if msg.Err == nil {
+			m.menu.SetDisplayMode(msg.DisplayMode)
+			m.favoritesOnly = msg.FavoritesOnly
+			m.alertMode = msg.Alert
+			// A session started from the CLI is already running
+			m.timer.alert = msg.Alert
+			m.timer.minimal = msg.MinimalTimer
+			m.timer.durations = msg.Durations
+			m.timer.quoteMax = msg.QuoteMax
+			m.durations = msg.Durations
+			m.quoteMax = msg.QuoteMax
+			m.minimalTimer = msg.MinimalTimer
+			m.skipSplash = msg.SkipSplash
+			m.nightAfter = msg.NightAfter
+			SetReducedMotion(msg.ReducedMotion)
+			notify.SetEnabled(msg.Notifications)
+			m.checkNightMode()
+		}
+		return m, nil
+
+	case AlertChangedMsg:
+		if msg.Err == nil {
+			m.alertMode = msg.Mode
+		}
+		newSettings, cmd := m.settings.Update(msg)
+		m.settings = newSettings.(SettingsModel)
+		return m, cmd
+
+	case StreakFreezesChangedMsg, StreakAbandonedChangedMsg:
+		// The menu footer's streak follows the new rules
+		newSettings, cmd := m.settings.Update(msg)
+		m.settings = newSettings.(SettingsModel)
+		return m, tea.Batch(cmd, loadStatsCmd())
+
+	case AutoStartChangedMsg:
+		if msg.Err == nil {
+			m.durations.HoldBreaks, m.durations.AutoStartWork = msg.HoldBreaks, msg.AutoStartWork
+		}
+		newSettings, cmd := m.settings.Update(msg)
+		m.settings = newSettings.(SettingsModel)
+		return m, cmd
+
+	case ReducedMotionChangedMsg:
+		if msg.Err == nil {
+			SetReducedMotion(msg.On)
+		}
+		newSettings, cmd := m.settings.Update(msg)
+		m.settings = newSettings.(SettingsModel)
+		return m, cmd
+
+	case NotificationsChangedMsg:
+		if msg.Err == nil {
+			notify.SetEnabled(msg.On)
+		}
+		newSettings, cmd := m.settings.Update(msg)
+		m.settings = newSettings.(SettingsModel)
+		return m, cmd
+
+	case SplashChangedMsg:
+		if msg.Err == nil {
+			m.skipSplash = !msg.Show
+		}
+		newSettings, cmd := m.settings.Update(msg)
+		m.settings = newSettings.(SettingsModel)
+		return m, cmd
+
+	case DurationsSavedMsg:
+		if msg.Err == nil {
+			m.durations = msg.Durations
+			m.quoteMax = msg.QuoteMax
+			m.weeklyGoal = msg.WeeklyGoal
+			m.menu.SetWeeklyProgress(m.weekMinutes, m.weeklyGoal)
 		}
+		// Let the settings view show the result too
+		newSettings, cmd := m.settings.Update(msg)
+		m.settings = newSettings.(SettingsModel)
+		return m, cmd
+
+	case WyrdSharedMsg:
+		m.wyrdErr = msg.Err
+		m.wyrdStatus = ""
+		if msg.Err == nil {
+			m.wyrdStatus = "Your wyrd is woven — copied to the clipboard."
+			if msg.Destination != "clipboard" {
+				m.wyrdStatus = "Your wyrd is woven — written to " + msg.Destination
+			}
+		}
+		return m, nil
+
+	case StatsExportedMsg:
+		m.exportErr = msg.Err
+		m.exportStatus = ""
+		if msg.Err == nil {
+			m.exportStatus = "Review written to " + msg.Path
+		}
+		return m, nil
+
+	case ActiveSessionFoundMsg:
+		// Only interrupt the menu; a session started from the CLI replaces the record.
+		// Behind the splash, the offer waits until the vow is dismissed.
+		if msg.Err == nil && msg.Session != nil {
+			switch m.currentView {
+			case MenuViewState:
+				m.recovered = msg.Session
+				m.currentView = RecoveryViewState
+			case SplashViewState:
+				m.recovered = msg.Session
+			}
+		}
+		return m, nil
+
+	case FavoritesOnlyChangedMsg:
+		m.favoritesOnly = bool(msg)
+		return m, nil
+
+	case MinimalTimerChangedMsg:
+		m.minimalTimer = bool(msg)
 		return m, nil
 
+	case LastSubjectMsg:
+		// No history to continue from; pick a subject as usual
+		if msg.Subject == nil {
+			m.subjectSelect = NewSubjectSelectModel()
+			m.currentView = SubjectSelectViewState
+			return m, m.subjectSelect.LoadSubjects()
+		}
+		return m, func() tea.Msg { return IntentionSetMsg{Subject: *msg.Subject} }
+
 	case MenuSelectionMsg:
 		switch MenuChoice(msg) {
 		case StartSession:
 			m.subjectSelect = NewSubjectSelectModel()
 			m.currentView = SubjectSelectViewState
 			return m, m.subjectSelect.LoadSubjects()
+		case ContinueSession:
+			return m, loadLastSubjectCmd()
 		case ViewStats:
 			m.currentView = StatsViewState
-			return m, func() tea.Msg {
-				stats, err := db.GetSessionStats()
-				return StatsLoadedMsg{Stats: stats, Err: err}
+			m.wyrdStatus, m.wyrdErr = "", nil
+			m.exportStatus, m.exportErr = "", nil
+			m.showHabits = false
+			m.showCalendar = false
+			if m.statsRange != rangeAllTime {
+				m.rangeStats = nil
+				return m, tea.Batch(loadStatsCmd(), loadRangeStatsCmd(m.statsRange))
 			}
+			return m, loadStatsCmd()
+		case ViewHistory:
+			m.history = NewHistoryModel()
+			m.currentView = HistoryViewState
+			return m, m.history.LoadHistory()
+		case BrowsePoems:
+			m.poems = NewPoemsModel()
+			m.currentView = PoemsViewState
+			return m, m.poems.Init()
+		case LogSession:
+			m.manualEntry = NewManualEntryModel()
+			m.currentView = ManualEntryViewState
+			return m, m.manualEntry.Init()
+		case OpenSettings:
+			m.settings = NewSettingsModel()
+			m.settings.alert = m.alertMode
+			m.settings.splash = !m.skipSplash
+			m.settings.nightAfter = m.nightAfter
+			m.settings.stillTimer = ReducedMotion
+			m.settings.notifications = notify.Enabled
+			m.currentView = SettingsViewState
+			return m, m.settings.Init()
 		case ManageQuotes:
 			m.quotes = NewQuotesModel()
+			m.quotes.favoritesOnly = m.favoritesOnly
 			m.currentView = QuotesViewState
 			return m, m.quotes.LoadQuotes()
 		case QuitApp:
@@ -86,7 +660,23 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case SubjectSelectedMsg:
-		m.timer = NewTimerModelWithMode(25, msg.Subject.ID.Hex(), msg.Subject.Name, m.menu.GetDisplayMode())
+		m.intention = NewIntentionModel(msg.Subject)
+		m.currentView = IntentionViewState
+		return m, m.intention.Init()
+
+	case IntentionSetMsg:
+		m.timer = NewTimerModelWithOptions(msg.Subject.SessionMinutes(m.durations.Work), msg.Subject.ID.Hex(), msg.Subject.Name, TimerOptions{
+			Intention:     msg.Intention,
+			Tags:          msg.Tags,
+			DisplayMode:   m.menu.GetDisplayMode(),
+			FavoritesOnly: m.favoritesOnly,
+			StrictQuotes:  msg.Subject.StrictQuotes,
+			Alert:         m.alertMode,
+			Durations:     m.durations,
+			QuoteMax:      m.quoteMax,
+			Minimal:       m.minimalTimer,
+		})
+		m.timer.width, m.timer.height = m.width, m.height
 		m.currentView = TimerViewState
 		return m, m.timer.Init()
 
@@ -94,22 +684,59 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.currentView = MenuViewState
 		return m, nil
 
+	case SessionLoggedMsg:
+		// A failed save stays on the form so nothing typed is lost
+		if msg.Err == nil {
+			m.currentView = MenuViewState
+			return m, loadStatsCmd()
+		}
+
 	case TimerCompleteMsg:
-		// Save session to database
-		status := db.StatusCompleted
-		if !msg.Completed {
-			status = db.StatusAbandoned
+		next := MenuViewState
+		if msg.OnBreak {
+			next = TimerViewState
 		}
+		m.currentView = next
 
-		subjectID, _ := primitive.ObjectIDFromHex(msg.SubjectID)
-		db.CreateSession(subjectID, msg.SubjectName, msg.Duration, status, msg.StartedAt)
+		// A kept vow stays on the completed screen until a key is pressed,
+		// which starts any break and moves on to whatever comes next
+		if msg.Completed {
+			m.afterComplete = next
+			m.currentView = TimerViewState
+		}
+		return m, saveSessionCmd(msg)
 
-		// Reload stats for streak update
-		m.currentView = MenuViewState
-		return m, func() tea.Msg {
-			stats, _ := db.GetSessionStats()
-			return StatsLoadedMsg{Stats: stats}
+	case SessionSavedMsg:
+		m.timer.saveErr = msg.Err
+		m.menu.SetSaveError(msg.Err)
+		if msg.Completed && msg.Err == nil {
+			next := MenuViewState
+			if msg.OnBreak {
+				next = TimerViewState
+			}
+			view := next
+			m.afterMilestone = next
+			if msg.Milestone > 0 || msg.Record > 0 {
+				m.milestone, m.record, m.milestoneFrom = msg.Milestone, msg.Record, msg.Session.SubjectName
+				view = MilestoneViewState
+			}
+
+			// A kept vow is reflected on before any celebration
+			m.afterReflection = view
+			m.reflection = NewReflectionModel(msg.Session.ID, msg.Session.SubjectName)
+			view = ReflectionViewState
+
+			// Still on the completed screen, the key press leads here; if it
+			// came before the save finished, go on from where it led
+			if m.currentView == TimerViewState && m.timer.completed() {
+				m.afterComplete = view
+			} else if m.currentView == next {
+				m.currentView = view
+			}
 		}
+
+		// Reload stats for streak update
+		return m, loadStatsCmd()
 	}
 
 	// Route messages to the active view
@@ -124,16 +751,123 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.subjectSelect = newSubjectSelect.(SubjectSelectModel)
 		return m, cmd
 
+	case IntentionViewState:
+		newIntention, cmd := m.intention.Update(msg)
+		m.intention = newIntention.(IntentionModel)
+		return m, cmd
+
 	case TimerViewState:
 		newTimer, cmd := m.timer.Update(msg)
 		m.timer = newTimer.(TimerModel)
 		return m, cmd
 
 	case StatsViewState:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && m.showCalendar {
+			switch keyMsg.String() {
+			case "esc", "q", "c":
+				m.showCalendar = false
+				return m, nil
+			}
+			newCalendar, cmd := m.calendar.Update(msg)
+			m.calendar = newCalendar.(CalendarModel)
+			return m, cmd
+		}
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			if keyMsg.String() == "esc" || keyMsg.String() == "q" {
+			switch keyMsg.String() {
+			case "esc", "q":
+				if m.showHabits {
+					m.showHabits = false
+					return m, nil
+				}
 				m.currentView = MenuViewState
 				return m, nil
+			case "t":
+				m.showHabits = !m.showHabits
+				if m.showHabits {
+					m.habits = HabitsLoadedMsg{}
+					return m, loadHabitsCmd()
+				}
+				return m, nil
+			case "c":
+				m.showHabits = false
+				m.showCalendar = true
+				m.calendar = NewCalendarModel()
+				return m, m.calendar.Init()
+			case "w":
+				m.wyrdStatus, m.wyrdErr = "Weaving...", nil
+				return m, shareWyrdCmd()
+			case "e":
+				m.exportStatus, m.exportErr = "Writing...", nil
+				return m, exportStatsCmd()
+			case "r":
+				if m.showHabits {
+					return m, nil
+				}
+				m.statsRange = m.statsRange.Next()
+				m.rangeStats = nil
+				if m.statsRange == rangeAllTime {
+					return m, nil
+				}
+				return m, loadRangeStatsCmd(m.statsRange)
+			}
+		}
+
+	case SplashViewState:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			if keyMsg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m.currentView = MenuViewState
+			if m.recovered != nil {
+				m.currentView = RecoveryViewState
+			}
+			return m, nil
+		}
+
+	case ErrorViewState:
+		newError, cmd := m.errorView.Update(msg)
+		m.errorView = newError.(ErrorModel)
+		return m, cmd
+
+	case ReflectionViewState:
+		// A failed save stays on screen so it can be retried or skipped
+		if done, ok := msg.(ReflectionDoneMsg); ok && done.Err == nil {
+			m.currentView = m.afterReflection
+			return m, nil
+		}
+		newReflection, cmd := m.reflection.Update(msg)
+		m.reflection = newReflection.(ReflectionModel)
+		// Keep a break that started behind the reflection counting down
+		if _, isKey := msg.(tea.KeyMsg); !isKey && m.timerBehind(m.afterReflection) {
+			newTimer, timerCmd := m.timer.Update(msg)
+			m.timer = newTimer.(TimerModel)
+			cmd = tea.Batch(cmd, timerCmd)
+		}
+		return m, cmd
+
+	case MilestoneViewState:
+		if _, ok := msg.(tea.KeyMsg); ok {
+			m.currentView = m.afterMilestone
+			return m, nil
+		}
+		// Keep a break that started behind the celebration counting down
+		if m.afterMilestone == TimerViewState {
+			newTimer, cmd := m.timer.Update(msg)
+			m.timer = newTimer.(TimerModel)
+			return m, cmd
+		}
+
+	case RecoveryViewState:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "r", "enter":
+				return m.resumeRecovered()
+			case "d", "esc":
+				m.recovered = nil
+				m.currentView = MenuViewState
+				return m, clearActiveSessionCmd()
+			case "ctrl+c":
+				return m, tea.Quit
 			}
 		}
 
@@ -141,12 +875,125 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		newQuotes, cmd := m.quotes.Update(msg)
 		m.quotes = newQuotes.(QuotesModel)
 		return m, cmd
+
+	case HistoryViewState:
+		newHistory, cmd := m.history.Update(msg)
+		m.history = newHistory.(HistoryModel)
+		return m, cmd
+
+	case SettingsViewState:
+		newSettings, cmd := m.settings.Update(msg)
+		m.settings = newSettings.(SettingsModel)
+		return m, cmd
+
+	case ManualEntryViewState:
+		newEntry, cmd := m.manualEntry.Update(msg)
+		m.manualEntry = newEntry.(ManualEntryModel)
+		return m, cmd
+
+	case PoemsViewState:
+		newPoems, cmd := m.poems.Update(msg)
+		m.poems = newPoems.(PoemsModel)
+		return m, cmd
 	}
 
 	return m, nil
 }
 
+// checkNightMode dims or restores the palette for the current hour,
+// unless ctrl+n has forced it
+func (m *AppModel) checkNightMode() {
+	if !m.nightForced {
+		m.setDimmed(NightModeActive(m.nightAfter, time.Now()))
+	}
+}
+
+// setDimmed switches night mode and rebuilds the timer's progress bar,
+// which keeps the colours it was created with
+func (m *AppModel) setDimmed(on bool) {
+	if on == Dimmed {
+		return
+	}
+	SetDimmed(on)
+	m.timer.progress = NewProgressBar()
+}
+
+// resumeRecovered restarts the timer from an unfinished session
+func (m AppModel) resumeRecovered() (tea.Model, tea.Cmd) {
+	a := *m.recovered
+	m.recovered = nil
+	strict := false
+	if subject, err := db.GetSubjectByID(a.SubjectID); err == nil {
+		strict = subject.StrictQuotes
+	}
+	m.timer = NewTimerModelWithOptions(a.Duration, a.SubjectID.Hex(), a.SubjectName, TimerOptions{
+		DisplayMode:   m.menu.GetDisplayMode(),
+		FavoritesOnly: m.favoritesOnly,
+		StrictQuotes:  strict,
+		Alert:         m.alertMode,
+		Durations:     m.durations,
+		Minimal:       m.minimalTimer,
+		QuoteMax:      m.quoteMax,
+	})
+	m.timer.resumeFrom(a)
+	m.timer.intention = a.Intention
+	m.timer.tags = a.Tags
+	m.timer.width, m.timer.height = m.width, m.height
+	m.currentView = TimerViewState
+	return m, m.timer.Init()
+}
+
+// loadErr returns the error carried by a message that loads data for a view
+func loadErr(msg tea.Msg) error {
+	switch msg := msg.(type) {
+	case StatsLoadedMsg:
+		return msg.Err
+	case HabitsLoadedMsg:
+		return msg.Err
+	case CalendarLoadedMsg:
+		return msg.Err
+	case RangeStatsLoadedMsg:
+		return msg.Err
+	case SettingsLoadedMsg:
+		return msg.Err
+	case SubjectsLoadedMsg:
+		return msg.Err
+	case QuotesLoadedMsg:
+		return msg.Err
+	case HistoryLoadedMsg:
+		return msg.Err
+	case PoemsLoadedMsg:
+		return msg.Err
+	case DurationsLoadedMsg:
+		return msg.Err
+	}
+	return nil
+}
+
+// isTyping reports whether the current view has a text field accepting input
+func (m AppModel) isTyping() bool {
+	switch m.currentView {
+	case SubjectSelectViewState:
+		return m.subjectSelect.adding
+	case IntentionViewState:
+		return true
+	case ReflectionViewState:
+		return m.reflection.typing()
+	case QuotesViewState:
+		return m.quotes.adding
+	case ManualEntryViewState:
+		return true
+	case PoemsViewState:
+		return m.poems.filtering || m.poems.editing
+	}
+	return false
+}
+
 func (m AppModel) View() string {
+	if m.showHelp {
+		return renderHelp(m.currentView)
+	}
+
 	switch m.currentView {
 	case MenuViewState:
 		return m.menu.View()
@@ -158,13 +1005,60 @@ func (m AppModel) View() string {
 		return m.renderStats()
 	case QuotesViewState:
 		return m.quotes.View()
+	case HistoryViewState:
+		return m.history.View()
+	case SettingsViewState:
+		return m.settings.View()
+	case RecoveryViewState:
+		return renderRecovery(*m.recovered)
+	case MilestoneViewState:
+		return renderMilestone(m.milestone, m.record, m.milestoneFrom)
+	case SplashViewState:
+		return renderSplash()
+	case IntentionViewState:
+		return m.intention.View()
+	case ReflectionViewState:
+		return m.reflection.View()
+	case ErrorViewState:
+		return m.errorView.View()
+	case ManualEntryViewState:
+		return m.manualEntry.View()
+	case PoemsViewState:
+		return m.poems.View()
 	default:
 		return "Unknown view"
 	}
 }
 
+// formatPaused formats a number of seconds as "1h 5m", "12m" or "40s"
+func formatPaused(seconds int) string {
+	switch {
+	case seconds >= 3600:
+		return fmt.Sprintf("%dh %dm", seconds/3600, (seconds%3600)/60)
+	case seconds >= 60:
+		return fmt.Sprintf("%dm", seconds/60)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+// formatLongest formats the longest block as "90m (Writing)"
+func formatLongest(s *db.Session) string {
+	if s == nil {
+		return "—"
+	}
+	return fmt.Sprintf("%dm (%s)", s.Duration, s.SubjectName)
+}
+
 func (m AppModel) renderStats() string {
-	title := TitleStyle.Render("📜 Statistics")
+	if m.showCalendar {
+		return m.calendar.View()
+	}
+	if m.showHabits {
+		return m.renderHabits()
+	}
+
+	title := TitleStyle.Render(T("stats.title"))
 
 	if m.statsErr != nil {
 		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n",
@@ -174,7 +1068,12 @@ func (m AppModel) renderStats() string {
 		)
 	}
 
-	if m.stats == nil {
+	// Session counts follow the chosen range; streaks and badges stay all-time
+	s := m.stats
+	if m.statsRange != rangeAllTime {
+		s = m.rangeStats
+	}
+	if m.stats == nil || s == nil {
 		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n",
 			title,
 			NormalStyle.Render("Loading..."),
@@ -182,8 +1081,6 @@ func (m AppModel) renderStats() string {
 		)
 	}
 
-	s := m.stats
-
 	// Format hours and minutes
 	hours := s.TotalMinutes / 60
 	minutes := s.TotalMinutes % 60
@@ -200,33 +1097,91 @@ func (m AppModel) renderStats() string {
 		"%s\n\n"+
 			"  %sSessions Completed:  %d\n"+
 			"  %sSessions Abandoned:  %d\n"+
-			"  %sTotal Focus Time:    %s\n\n"+
+			"  %s\n"+
+			"  %sTotal Focus Time:    %s\n"+
+			"  %sTime Paused:         %s\n"+
+			"  %sLongest Block:       %s\n\n"+
 			"%s\n\n"+
 			"  %sCurrent Streak:      %d days\n"+
 			"  %sLongest Streak:      %d days",
-		SelectedStyle.Render("Sessions"),
+		SelectedStyle.Render("Sessions · "+m.statsRange.Label()),
 		IconStyle.Render("✓"), s.CompletedSessions,
 		IconStyle.Render("💀"), s.AbandonedSessions,
+		renderVowRatio(s.CompletedSessions, s.AbandonedSessions),
 		IconStyle.Render("⏱"), timeStr,
-		SelectedStyle.Render("Streaks"),
-		IconStyle.Render("⚡"), s.CurrentStreak,
-		IconStyle.Render("🏆"), s.LongestStreak,
+		IconStyle.Render("⏸"), formatPaused(s.PausedSeconds),
+		IconStyle.Render("🗡"), formatLongest(m.longest),
+		SelectedStyle.Render(T("stats.streaks")),
+		IconStyle.Render("⚡"), m.stats.CurrentStreak,
+		IconStyle.Render("🏆"), m.stats.LongestStreak,
 	)
 
-	// Get sessions by subject
-	bySubject, err := db.GetSessionsBySubject()
-	if err == nil && len(bySubject) > 0 {
-		statsDisplay += "\n\n" + SelectedStyle.Render("By Subject") + "\n"
-		for name, count := range bySubject {
-			statsDisplay += fmt.Sprintf("\n  %s: %d sessions", name, count)
+	statsDisplay += "\n\n" + SelectedStyle.Render(T("stats.week")) + "\n\n  " + renderWeeklyGoal(m.weekMinutes, m.weeklyGoal)
+
+	// Milestone badges derived from the stats
+	if badges := db.BadgesFor(*m.stats); len(badges) > 0 {
+		statsDisplay += "\n\n" + SelectedStyle.Render(T("stats.badges")) + "\n"
+		for _, badge := range badges {
+			statsDisplay += fmt.Sprintf("\n  %s%s %s",
+				IconStyle.Render(badge.Icon),
+				NormalStyle.Render(badge.Name),
+				HelpStyle.Render("— "+badge.Description),
+			)
 		}
 	}
 
-	// My Wyrd link
+	// Minutes per subject as a bar chart
+	if len(m.minutesBySubj) > 0 {
+		statsDisplay += "\n\n" + SelectedStyle.Render(T("stats.subjects")) + "\n\n" + renderSubjectChart(m.minutesBySubj, m.ratingBySubj)
+	}
+	if len(m.minutesByTag) > 0 {
+		statsDisplay += "\n\n" + SelectedStyle.Render(T("stats.tags")) + "\n\n" + renderSubjectChart(m.minutesByTag, nil)
+	}
+
+	// My Wyrd share
+	wyrdAction := HelpStyle.Render("press w to copy a shareable summary")
+	if m.wyrdErr != nil {
+		wyrdAction = ErrorStyle.Render("Could not share: " + m.wyrdErr.Error())
+	} else if m.wyrdStatus != "" {
+		wyrdAction = SuccessStyle.Render(m.wyrdStatus)
+	}
 	wyrdLink := "\n\n" + SelectedStyle.Render("Share Your Journey") + "\n\n" +
-		"  " + IconStyle.Render("🌐") + NormalStyle.Render("My Wyrd: ") + HelpStyle.Render("coming soon...")
+		"  " + IconStyle.Render("🌐") + NormalStyle.Render("My Wyrd: ") + wyrdAction
+
+	exportAction := HelpStyle.Render("press e to write a markdown report")
+	if m.exportErr != nil {
+		exportAction = ErrorStyle.Render("Could not export: " + m.exportErr.Error())
+	} else if m.exportStatus != "" {
+		exportAction = SuccessStyle.Render(m.exportStatus)
+	}
+	wyrdLink += "\n  " + IconStyle.Render("📝") + NormalStyle.Render("Weekly review: ") + exportAction
 
-	help := HelpStyle.Render("esc/q back to menu")
+	help := HelpStyle.Render("r range • t time of day • c calendar • w share • e export • esc/q back to menu")
 
 	return fmt.Sprintf("\n  %s\n\n%s%s\n\n  %s\n", title, statsDisplay, wyrdLink, help)
 }
+
+// renderHabits is the stats sub-view showing when sessions get completed
+func (m AppModel) renderHabits() string {
+	title := TitleStyle.Render("📜 Focus Habits")
+	help := HelpStyle.Render("t/esc back to statistics")
+
+	var body string
+	switch {
+	case m.habits.Err != nil:
+		body = "  " + ErrorStyle.Render("Error loading habits: "+m.habits.Err.Error())
+	case m.habits.ByHour == nil:
+		body = "  " + NormalStyle.Render("Loading...")
+	case len(m.habits.ByHour) == 0:
+		body = "  " + NormalStyle.Render("No completed sessions yet.")
+	default:
+		hour, count := bestHour(m.habits.ByHour)
+		body = SelectedStyle.Render("Sessions by Hour") + "\n\n" +
+			renderHourHistogram(m.habits.ByHour) + "\n\n" +
+			"  " + NormalStyle.Render(fmt.Sprintf("You focus best at %02d:00–%02d:00 (%d sessions)", hour, (hour+1)%24, count)) + "\n\n" +
+			SelectedStyle.Render("Minutes by Weekday") + "\n\n" +
+			renderWeekdayChart(m.habits.ByWeekday, m.habits.WeekStart)
+	}
+
+	return fmt.Sprintf("\n  %s\n\n%s\n\n  %s\n", title, body, help)
+}
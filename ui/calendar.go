@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"Beot/db"
+)
+
+// calendarCellWidth is the width of one day in the month grid
+const calendarCellWidth = 4
+
+// CalendarModel is the stats sub-view showing one month of focus days
+type CalendarModel struct {
+	month     time.Time // Local midnight on the first of the month shown
+	selected  int       // Day of the month whose total is shown under the grid
+	byDay     map[int]int
+	weekStart time.Weekday
+	loaded    bool
+	err       error
+}
+
+// CalendarLoadedMsg carries one month's minutes by day of the month
+type CalendarLoadedMsg struct {
+	Year      int
+	Month     time.Month
+	ByDay     map[int]int
+	WeekStart time.Weekday
+	Err       error
+}
+
+// NewCalendarModel opens the calendar on the current month with today selected
+func NewCalendarModel() CalendarModel {
+	now := time.Now()
+	return CalendarModel{
+		month:     time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()),
+		selected:  now.Day(),
+		weekStart: time.Monday,
+	}
+}
+
+func (m CalendarModel) Init() tea.Cmd {
+	return loadCalendarCmd(m.month.Year(), m.month.Month())
+}
+
+// loadCalendarCmd fetches a month of activity and the first day of the week
+func loadCalendarCmd(year int, month time.Month) tea.Cmd {
+	return func() tea.Msg {
+		byDay, err := db.GetActivityForMonth(year, month)
+		if err != nil {
+			return CalendarLoadedMsg{Year: year, Month: month, Err: err}
+		}
+		start, err := db.GetWeekStart()
+		return CalendarLoadedMsg{Year: year, Month: month, ByDay: byDay, WeekStart: start, Err: err}
+	}
+}
+
+func (m CalendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case CalendarLoadedMsg:
+		// Paging quickly can deliver an older month after a newer one
+		if msg.Year != m.month.Year() || msg.Month != m.month.Month() {
+			return m, nil
+		}
+		m.byDay, m.weekStart, m.err, m.loaded = msg.ByDay, msg.WeekStart, msg.Err, true
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left":
+			return m.showMonth(-1)
+		case "right":
+			return m.showMonth(1)
+		case "up", "k":
+			m.selected = max(m.selected-1, 1)
+		case "down", "j":
+			m.selected = min(m.selected+1, daysIn(m.month))
+		}
+	}
+	return m, nil
+}
+
+// showMonth moves delta months, keeping the selected day where it exists
+func (m CalendarModel) showMonth(delta int) (tea.Model, tea.Cmd) {
+	m.month = m.month.AddDate(0, delta, 0)
+	m.selected = min(m.selected, daysIn(m.month))
+	m.byDay, m.err, m.loaded = nil, nil, false
+	return m, loadCalendarCmd(m.month.Year(), m.month.Month())
+}
+
+// daysIn returns the number of days in the month holding t
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
+
+func (m CalendarModel) View() string {
+	title := TitleStyle.Render("📅 " + m.month.Format("January 2006"))
+	help := HelpStyle.Render("←/→ month • ↑/↓ day • c/esc back to statistics")
+
+	var body string
+	switch {
+	case m.err != nil:
+		body = "  " + ErrorStyle.Render("Error loading calendar: "+m.err.Error())
+	case !m.loaded:
+		body = "  " + NormalStyle.Render("Loading...")
+	default:
+		body = renderCalendar(m.month, m.byDay, m.weekStart, time.Now(), m.selected)
+		day := time.Date(m.month.Year(), m.month.Month(), m.selected, 0, 0, 0, 0, m.month.Location())
+		detail := HelpStyle.Render("no focus")
+		if minutes := m.byDay[m.selected]; minutes > 0 {
+			detail = StreakStyle.Render(formatMinutes(minutes))
+		}
+		body += "\n\n  " + NormalStyle.Render(day.Format("Mon 2 Jan")+": ") + detail
+
+		total := 0
+		for _, minutes := range m.byDay {
+			total += minutes
+		}
+		body += "\n  " + NormalStyle.Render(fmt.Sprintf("%d focus days this month, ", len(m.byDay))) + StreakStyle.Render(formatMinutes(total))
+	}
+
+	return fmt.Sprintf("\n  %s\n\n%s\n\n  %s\n", title, body, help)
+}
+
+// renderCalendar draws month as a grid of weeks starting on weekStart. Days
+// with focus are coloured, the rest muted; today is underlined and the
+// selected day reversed.
+func renderCalendar(month time.Time, byDay map[int]int, weekStart time.Weekday, today time.Time, selected int) string {
+	cell := lipgloss.NewStyle().Width(calendarCellWidth).Align(lipgloss.Right)
+
+	// weekdayNames is Monday first
+	first := (int(weekStart) + 6) % 7
+	header := ""
+	for i := range weekdayNames {
+		header += cell.Render(weekdayNames[(first+i)%7][:2])
+	}
+	rows := []string{"  " + HelpStyle.Render(header)}
+
+	offset := (int(month.Weekday()) - int(weekStart) + 7) % 7
+	row := strings.Repeat(" ", offset*calendarCellWidth)
+	for day := 1; day <= daysIn(month); day++ {
+		style := HelpStyle
+		if byDay[day] > 0 {
+			style = StreakStyle
+		}
+		if today.Year() == month.Year() && today.Month() == month.Month() && today.Day() == day {
+			style = style.Bold(true).Underline(true)
+		}
+		if day == selected {
+			style = style.Reverse(true)
+		}
+		row += strings.Repeat(" ", calendarCellWidth-2) + style.Render(fmt.Sprintf("%2d", day))
+
+		if (offset+day)%7 == 0 {
+			rows = append(rows, "  "+row)
+			row = ""
+		}
+	}
+	if row != "" {
+		rows = append(rows, "  "+row)
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderCalendar(t *testing.T) {
+	// 1 March 2026 is a Sunday
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	today := march.AddDate(0, 0, 16)
+
+	got := renderCalendar(march, map[int]int{1: 25}, time.Monday, today, 17)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 7 {
+		t.Fatalf("a Monday-first March 2026 needs a header and 6 weeks, got %d lines:\n%s", len(lines), got)
+	}
+	if want := "    Mo  Tu  We  Th  Fr  Sa  Su"; lines[0] != want {
+		t.Errorf("header = %q, want %q", lines[0], want)
+	}
+	if want := strings.Repeat(" ", 2+6*calendarCellWidth) + "   1"; lines[1] != want {
+		t.Errorf("first week = %q, want the 1st alone under Sunday", lines[1])
+	}
+	if want := "     2   3   4   5   6   7   8"; lines[2] != want {
+		t.Errorf("second week = %q, want %q", lines[2], want)
+	}
+	if !strings.HasSuffix(lines[6], "  30  31") {
+		t.Errorf("last week = %q, want it to end on the 31st", lines[6])
+	}
+
+	got = renderCalendar(march, nil, time.Sunday, today, 1)
+	if lines := strings.Split(got, "\n"); len(lines) != 6 || lines[1] != "     1   2   3   4   5   6   7" {
+		t.Errorf("a Sunday-first March 2026 should open on a full week, got:\n%s", got)
+	}
+}
+
+func TestCalendarIgnoresStaleMonth(t *testing.T) {
+	m := NewCalendarModel()
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m = model.(CalendarModel)
+
+	// The month just left arrives after the switch
+	prev := m.month.AddDate(0, -1, 0)
+	model, _ = m.Update(CalendarLoadedMsg{Year: prev.Year(), Month: prev.Month(), ByDay: map[int]int{1: 25}})
+	if model.(CalendarModel).loaded {
+		t.Error("a month no longer shown should be ignored")
+	}
+
+	model, _ = m.Update(CalendarLoadedMsg{Year: m.month.Year(), Month: m.month.Month(), ByDay: map[int]int{1: 25}})
+	if m = model.(CalendarModel); !m.loaded || m.byDay[1] != 25 {
+		t.Error("the month shown should load")
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+// ErrorModel is a full-screen error. At startup, e.g. when MongoDB is
+// unreachable, any key exits; inside the app it can also retry.
+type ErrorModel struct {
+	title     string
+	err       error
+	retryable bool // r checks the connection again instead of exiting
+	retrying  bool // A retry is in flight
+}
+
+// ReconnectedMsg reports the result of retrying after a lost connection
+type ReconnectedMsg struct {
+	Err error
+}
+
+// NewErrorModel creates an error screen with a short title and the cause
+func NewErrorModel(title string, err error) ErrorModel {
+	return ErrorModel{title: title, err: err}
+}
+
+// NewRetryableErrorModel creates an error screen that offers to reconnect
+func NewRetryableErrorModel(title string, err error) ErrorModel {
+	return ErrorModel{title: title, err: err, retryable: true}
+}
+
+func (m ErrorModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ErrorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ReconnectedMsg:
+		m.retrying = false
+		if msg.Err != nil {
+			m.err = msg.Err
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if !m.retryable {
+			return m, tea.Quit
+		}
+		switch msg.String() {
+		case "r", "enter":
+			if !m.retrying {
+				m.retrying = true
+				return m, reconnectCmd()
+			}
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// reconnectCmd checks whether the database can be reached again
+func reconnectCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ReconnectedMsg{Err: db.Ping()}
+	}
+}
+
+func (m ErrorModel) View() string {
+	title := ErrorStyle.Render("The hall is dark.")
+
+	message := NormalStyle.Render(m.title + ":")
+	cause := WarningStyle.Render(m.err.Error())
+
+	hint := HelpStyle.Render(
+		"Check that MongoDB is running and BEOT_MONGODB_URI is set\n" +
+			"in your environment or .env file (see .env.example),\n" +
+			"or set BEOT_STORAGE=local to run without MongoDB.",
+	)
+
+	help := HelpStyle.Render("Press any key to exit")
+	if m.retryable {
+		help = HelpStyle.Render("r retry • q quit")
+		if m.retrying {
+			help = NormalStyle.Render("Reconnecting...")
+		}
+	}
+
+	content := fmt.Sprintf(
+		"%s\n\n%s\n%s\n\n%s\n\n%s",
+		title,
+		message,
+		cause,
+		hint,
+		help,
+	)
+
+	return "\n" + RenderBanner() + "\n\n" + BoxStyle.Render(content) + "\n"
+}
@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"Beot/db"
+)
+
+func TestLostConnectionShowsErrorView(t *testing.T) {
+	m := NewAppModel()
+	next, _ := m.Update(StatsLoadedMsg{Err: db.ErrNotConnected})
+	m = next.(AppModel)
+	if m.currentView != ErrorViewState {
+		t.Fatalf("view = %v, want ErrorViewState", m.currentView)
+	}
+
+	next, _ = m.Update(ReconnectedMsg{})
+	m = next.(AppModel)
+	if m.currentView != MenuViewState {
+		t.Errorf("after reconnecting: view = %v, want MenuViewState", m.currentView)
+	}
+
+	// Ordinary failures stay inline in their own view
+	next, _ = m.Update(StatsLoadedMsg{Err: errors.New("bad document")})
+	if next.(AppModel).currentView != MenuViewState {
+		t.Error("a non-connection error replaced the view")
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keyBinding is one row in the help overlay
+type keyBinding struct {
+	keys string
+	desc string
+}
+
+// globalBindings are shown in every help overlay
+var globalBindings = []keyBinding{
+	{"?/h", "toggle this help"},
+	{"ctrl+n", "toggle night mode"},
+	{"ctrl+c", "quit immediately"},
+}
+
+// viewBindings lists the key bindings for each view; the menu and timer
+// come from their key maps instead, so remapped keys show as they are
+var viewBindings = map[View][]keyBinding{
+	SubjectSelectViewState: {
+		{"↑/k ↓/j", "move cursor"},
+		{"shift+↑/K shift+↓/J", "move subject up/down"},
+		{"enter/space", "start session with subject"},
+		{"a", "add a subject"},
+		{"o", "own quotes only (skip general ones)"},
+		{"x", "archive/restore subject"},
+		{"v", "show/hide archived subjects"},
+		{"D", "delete the subject's tagged quotes"},
+		{"unlisted letter", "jump to the next subject starting with it (listed keys keep their action)"},
+		{"esc/q", "back to menu"},
+	},
+	StatsViewState: {
+		{"r", "cycle range: all time, 7 days, 30 days"},
+		{"t", "focus habits by hour and weekday"},
+		{"c", "month calendar of focus days (←/→ month, ↑/↓ day)"},
+		{"w", "share My Wyrd summary"},
+		{"e", "write a markdown weekly review to a file"},
+		{"esc/q", "back to menu"},
+	},
+	QuotesViewState: {
+		{"↑/k ↓/j", "move cursor"},
+		{"a", "add a quote"},
+		{"d", "delete quote"},
+		{"u", "undo last delete"},
+		{"f", "toggle favorite"},
+		{"F", "favorites-only rotation"},
+		{"unlisted letter", "jump to the next quote starting with it (listed keys keep their action)"},
+		{"esc/q", "back to menu"},
+	},
+	HistoryViewState: {
+		{"↑/k ↓/j", "scroll"},
+		{"n", "show only sessions with notes"},
+		{"t", "exact times instead of \"3h ago\""},
+		{"d", "delete session"},
+		{"esc/q", "back to menu"},
+	},
+	IntentionViewState: {
+		{"enter", "begin the session (empty skips the vow)"},
+		{"tab", "switch between the vow and tags"},
+		{"esc", "back to menu"},
+	},
+	ReflectionViewState: {
+		{"1-5", "rate the session"},
+		{"enter", "save the rating and note"},
+		{"esc", "skip (after rating: save without a note)"},
+	},
+	MilestoneViewState: {
+		{"any key", "continue"},
+	},
+	SplashViewState: {
+		{"any key", "continue to the menu"},
+	},
+	RecoveryViewState: {
+		{"r/enter", "resume the unfinished session"},
+		{"d/esc", "discard it"},
+	},
+	ErrorViewState: {
+		{"r/enter", "retry the connection"},
+		{"q/esc", "quit"},
+	},
+	SettingsViewState: {
+		{"tab ↑ ↓", "switch field"},
+		{"space ← →", "change the option under the cursor"},
+		{"enter", "save"},
+		{"esc", "back to menu"},
+	},
+	PoemsViewState: {
+		{"↑/k ↓/j", "move cursor"},
+		{"/", "filter by source"},
+		{"e", "edit the poem (tab switches field, ctrl+j new line, enter saves)"},
+		{"esc", "clear filter, then back to menu"},
+		{"q", "back to menu"},
+	},
+	ManualEntryViewState: {
+		{"tab ↑ ↓", "switch field"},
+		{"← →", "change subject"},
+		{"enter", "next field / save"},
+		{"esc", "back to menu"},
+	},
+}
+
+// renderHelp renders the key binding overlay for a view
+func renderHelp(view View) string {
+	viewRows, ok := keyMapHelp(view)
+	if !ok {
+		viewRows = viewBindings[view]
+	}
+	bindings := append(append([]keyBinding{}, viewRows...), globalBindings...)
+
+	width := 0
+	for _, b := range bindings {
+		if w := len([]rune(b.keys)); w > width {
+			width = w
+		}
+	}
+
+	var rows []string
+	for _, b := range bindings {
+		pad := strings.Repeat(" ", width-len([]rune(b.keys)))
+		rows = append(rows, SelectedStyle.Render(b.keys)+pad+"  "+NormalStyle.Render(b.desc))
+	}
+
+	content := fmt.Sprintf(
+		"%s\n\n%s\n\n%s",
+		TitleStyle.Render("Keyboard Shortcuts"),
+		strings.Join(rows, "\n"),
+		HelpStyle.Render("?/h/esc close"),
+	)
+
+	return "\n" + BoxStyle.Render(content) + "\n"
+}
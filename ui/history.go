@@ -0,0 +1,293 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+// historyLimit is how many recent sessions the history view loads
+const historyLimit = 50
+
+// historyPageSize is how many sessions are visible at once
+const historyPageSize = 12
+
+type HistoryModel struct {
+	sessions []db.Session
+	icons    map[string]string // subject ID hex -> icon
+	cursor   int
+	offset   int // first visible row
+	loaded   bool
+	// confirming is true while asking whether to delete the selected session
+	confirming bool
+	// notesOnly narrows the list to sessions with a reflection note
+	notesOnly bool
+	// exactTimes shows full timestamps in place of "3h ago"
+	exactTimes bool
+	all        []db.Session // every loaded session, before the notes filter
+	err        error
+}
+
+func NewHistoryModel() HistoryModel {
+	return HistoryModel{}
+}
+
+type HistoryLoadedMsg struct {
+	Sessions []db.Session
+	Icons    map[string]string
+	Err      error
+}
+
+func (m *HistoryModel) LoadHistory() tea.Cmd {
+	return func() tea.Msg {
+		sessions, err := db.GetRecentSessions(historyLimit)
+		if err != nil {
+			return HistoryLoadedMsg{Err: err}
+		}
+
+		// Icons are looked up from subjects; missing ones fall back to a default
+		icons := make(map[string]string)
+		if subjects, err := db.GetSubjects(true); err == nil {
+			for _, s := range subjects {
+				icons[s.ID.Hex()] = s.Icon
+			}
+		}
+		return HistoryLoadedMsg{Sessions: sessions, Icons: icons}
+	}
+}
+
+type SessionDeletedMsg struct {
+	Err error
+}
+
+func (m HistoryModel) Init() tea.Cmd {
+	return m.LoadHistory()
+}
+
+func (m HistoryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case HistoryLoadedMsg:
+		m.loaded = true
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.all = msg.Sessions
+			m.icons = msg.Icons
+			m.applyFilter()
+			if m.cursor >= len(m.sessions) {
+				m.cursor = len(m.sessions) - 1
+			}
+			if m.cursor < 0 {
+				m.cursor = 0
+			}
+			m.scrollToCursor()
+		}
+		return m, nil
+
+	case SessionDeletedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		// Reload the list and the stats so streaks and totals reflect the deletion
+		return m, tea.Batch(m.LoadHistory(), loadStatsCmd())
+
+	case tea.KeyMsg:
+		if m.confirming {
+			switch msg.String() {
+			case "y":
+				m.confirming = false
+				return m, m.deleteCurrentSession()
+			case "n", "esc":
+				m.confirming = false
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "esc", "q":
+			return m, func() tea.Msg { return BackToMenuMsg{} }
+		case "d", "delete":
+			if len(m.sessions) > 0 {
+				m.confirming = true
+			}
+			return m, nil
+		case "t":
+			m.exactTimes = !m.exactTimes
+			return m, nil
+		case "n":
+			m.notesOnly = !m.notesOnly
+			m.applyFilter()
+			m.cursor, m.offset = 0, 0
+			return m, nil
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.sessions)-1 {
+				m.cursor++
+			}
+		}
+		m.scrollToCursor()
+	}
+
+	return m, nil
+}
+
+func (m HistoryModel) deleteCurrentSession() tea.Cmd {
+	if m.cursor >= len(m.sessions) {
+		return nil
+	}
+	id := m.sessions[m.cursor].ID
+	return func() tea.Msg {
+		err := db.DeleteSession(id)
+		return SessionDeletedMsg{Err: err}
+	}
+}
+
+// applyFilter rebuilds the visible list from the loaded sessions
+func (m *HistoryModel) applyFilter() {
+	if !m.notesOnly {
+		m.sessions = m.all
+		return
+	}
+	m.sessions = nil
+	for _, s := range m.all {
+		if s.Note != "" {
+			m.sessions = append(m.sessions, s)
+		}
+	}
+}
+
+// scrollToCursor keeps the cursor inside the visible window
+func (m *HistoryModel) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+historyPageSize {
+		m.offset = m.cursor - historyPageSize + 1
+	}
+}
+
+func (m HistoryModel) View() string {
+	title := TitleStyle.Render("🕰 Session History")
+
+	if m.err != nil {
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n",
+			title,
+			ErrorStyle.Render("Error: "+m.err.Error()),
+			HelpStyle.Render("esc/q back to menu"),
+		)
+	}
+
+	if !m.loaded {
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n",
+			title,
+			NormalStyle.Render("Loading..."),
+			HelpStyle.Render("esc/q back to menu"),
+		)
+	}
+
+	if len(m.sessions) == 0 && m.notesOnly {
+		empty := NormalStyle.Render("No session notes yet. Add one after completing a session.")
+		help := HelpStyle.Render("n show all sessions • esc/q back to menu")
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, empty, help)
+	}
+
+	if len(m.sessions) == 0 {
+		empty := NormalStyle.Render("No sessions yet. Your first vow awaits.")
+		help := HelpStyle.Render("esc/q back to menu")
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, empty, help)
+	}
+
+	end := m.offset + historyPageSize
+	if end > len(m.sessions) {
+		end = len(m.sessions)
+	}
+
+	var list string
+	for i := m.offset; i < end; i++ {
+		s := m.sessions[i]
+		cursor := "  "
+		style := NormalStyle
+		if i == m.cursor {
+			cursor = "▸ "
+			style = SelectedStyle
+		}
+
+		status := SuccessStyle.Render("✓")
+		if s.Status == db.StatusAbandoned {
+			status = ErrorStyle.Render("💀")
+		}
+
+		icon, ok := m.icons[s.SubjectID.Hex()]
+		if !ok || icon == "" {
+			icon = "📚"
+		}
+
+		line := fmt.Sprintf("%-16s %3dm", s.SubjectName, s.Duration)
+		list += fmt.Sprintf("%s%s %s%s  %s\n",
+			cursor,
+			IconStyle.Render(status),
+			renderIcon(icon),
+			style.Render(line),
+			HelpStyle.Render(m.formatTime(s.CompletedAt)),
+		)
+	}
+
+	position := HelpStyle.Render(fmt.Sprintf("%d of %d", m.cursor+1, len(m.sessions)))
+	if m.notesOnly {
+		position += HelpStyle.Render(" • notes only")
+	}
+	detail := m.renderDetail(m.sessions[m.cursor])
+	help := HelpStyle.Render("↑/↓ scroll • n notes only • t exact times • d delete • esc/q back")
+	if m.confirming {
+		help = WarningStyle.Render("Delete this session? It will no longer count towards your stats. [y] yes • [n] no")
+	}
+
+	return fmt.Sprintf("\n  %s\n\n%s\n  %s\n\n%s  %s\n", title, list, position, detail, help)
+}
+
+// renderDetail shows the reflection and the content that accompanied the
+// selected session
+func (m HistoryModel) renderDetail(s db.Session) string {
+	var detail string
+	if s.Rating > 0 || s.Note != "" {
+		detail = "  " + NormalStyle.Render("Reflection:")
+		if s.Rating > 0 {
+			detail += " " + renderRating(s.Rating)
+		}
+		detail += "\n"
+		if s.Note != "" {
+			detail += QuoteStyle.Render(s.Note) + "\n"
+		}
+		detail += "\n"
+	}
+
+	var shown string
+	switch {
+	case s.LastQuoteText != "":
+		shown = "\"" + s.LastQuoteText + "\""
+		if s.LastQuoteSource != "" {
+			shown += " — " + s.LastQuoteSource
+		}
+	case s.LastPoemRef != "":
+		shown = s.LastPoemRef
+	default:
+		return detail
+	}
+	return detail + "  " + NormalStyle.Render("You focused while reading:") + "\n" +
+		QuoteStyle.Render(shown) + "\n\n"
+}
+
+// formatTime shows when a session happened, relative unless exact times are on
+func (m HistoryModel) formatTime(t time.Time) string {
+	if m.exactTimes {
+		return ExactTime(t)
+	}
+	return RelativeTime(t)
+}
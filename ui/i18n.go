@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultLang is used when BEOT_LANG is unset or names no known language
+const DefaultLang = "en"
+
+// Lang is the language interface text is shown in
+var Lang = DefaultLang
+
+// translations holds interface text by language, then key. English is
+// complete; other languages fall back to it for anything missing. Entries
+// with verbs are format strings and must keep the same verbs in order.
+// Quotes and poems are content and are never translated.
+var translations = map[string]map[string]string{
+	"en": {
+		"menu.start":            "Start Focus Session",
+		"menu.continue":         "Continue Last Subject",
+		"menu.stats":            "View Statistics",
+		"menu.history":          "Session History",
+		"menu.log":              "Log Past Session",
+		"menu.quotes":           "Manage Quotes",
+		"menu.poems":            "Browse Poems",
+		"menu.display.quotes":   "Display: Quotes",
+		"menu.display.poems":    "Display: Old English Poems",
+		"menu.display.both":     "Display: Quotes & Poems",
+		"menu.theme":            "Theme: %s",
+		"menu.settings":         "Settings",
+		"menu.quit":             "Quit",
+		"menu.help":             "%s navigate • %s select • %s continue last subject • ? help • %s quit",
+		"menu.streak.none":      "Start a session to begin your streak!",
+		"menu.streak":           "⚡ %d day streak",
+		"menu.today":            "Today %s",
+		"menu.yesterday":        "%s vs yesterday",
+		"menu.yesterday.same":   "same as yesterday",
+		"timer.subject":         "Subject: %s",
+		"timer.vow.kept":        "You vowed: %s — kept.",
+		"timer.giveup.title":    "Give up?",
+		"timer.giveup.message":  "This will be logged as abandoned 💀",
+		"timer.giveup.help":     "[y] yes, abandon • [n] no, continue",
+		"timer.breakover.title": "The break is over.",
+		"timer.breakover.body":  "%s kept (%d so far).\nTake up your vow again when you are ready.",
+		"timer.breakover.help":  "enter begin next block • %s back to menu",
+		"timer.breakready.body": "%s kept (%d so far).\nA %d-minute break is ready when you are.",
+		"timer.breakready.help": "enter start break • %s skip break • %s back to menu",
+		"timer.complete.title":  "Your vow is kept.",
+		"timer.complete.body":   "You held to your word for %d minutes.\nYour honour remains unbroken.",
+		"timer.complete.help":   "Press any key to continue",
+		"timer.complete.today":  "Today: %s",
+		"stats.title":           "📜 Statistics",
+		"stats.streaks":         "Streaks",
+		"stats.week":            "This Week",
+		"stats.badges":          "Badges",
+		"stats.subjects":        "By Subject",
+		"stats.tags":            "By Tag",
+	},
+	"de": {
+		"menu.start":            "Fokus-Sitzung starten",
+		"menu.continue":         "Letztes Fach fortsetzen",
+		"menu.stats":            "Statistiken ansehen",
+		"menu.history":          "Sitzungsverlauf",
+		"menu.log":              "Vergangene Sitzung eintragen",
+		"menu.quotes":           "Zitate verwalten",
+		"menu.poems":            "Gedichte durchsuchen",
+		"menu.display.quotes":   "Anzeige: Zitate",
+		"menu.display.poems":    "Anzeige: Altenglische Gedichte",
+		"menu.display.both":     "Anzeige: Zitate & Gedichte",
+		"menu.theme":            "Farbschema: %s",
+		"menu.settings":         "Einstellungen",
+		"menu.quit":             "Beenden",
+		"menu.help":             "%s bewegen • %s auswählen • %s letztes Fach • ? Hilfe • %s beenden",
+		"menu.streak.none":      "Beginne eine Sitzung, um deine Serie zu starten!",
+		"menu.streak":           "⚡ %d Tage in Folge",
+		"menu.today":            "Heute %s",
+		"menu.yesterday":        "%s gegenüber gestern",
+		"menu.yesterday.same":   "wie gestern",
+		"timer.subject":         "Fach: %s",
+		"timer.vow.kept":        "Dein Schwur: %s — gehalten.",
+		"timer.giveup.title":    "Aufgeben?",
+		"timer.giveup.message":  "Dies wird als abgebrochen verbucht 💀",
+		"timer.giveup.help":     "[y] ja, aufgeben • [n] nein, weitermachen",
+		"timer.breakover.title": "Die Pause ist vorbei.",
+		"timer.breakover.body":  "%s gehalten (%d bisher).\nNimm deinen Schwur wieder auf, wenn du bereit bist.",
+		"timer.breakover.help":  "enter nächsten Block beginnen • %s zurück zum Menü",
+		"timer.breakready.body": "%s gehalten (%d bisher).\nEine %d-minütige Pause wartet auf dich.",
+		"timer.breakready.help": "enter Pause beginnen • %s Pause überspringen • %s zurück zum Menü",
+		"timer.complete.title":  "Dein Schwur ist gehalten.",
+		"timer.complete.body":   "Du hast %d Minuten lang Wort gehalten.\nDeine Ehre bleibt ungebrochen.",
+		"timer.complete.help":   "Beliebige Taste zum Fortfahren",
+		"timer.complete.today":  "Heute: %s",
+		"stats.title":           "📜 Statistik",
+		"stats.streaks":         "Serien",
+		"stats.week":            "Diese Woche",
+		"stats.badges":          "Abzeichen",
+		"stats.subjects":        "Nach Fach",
+		"stats.tags":            "Nach Schlagwort",
+	},
+}
+
+func init() {
+	SetLang(os.Getenv("BEOT_LANG"))
+}
+
+// SetLang switches the interface language by code, e.g. "de" or "de_DE.UTF-8".
+// Unknown languages fall back to English.
+func SetLang(code string) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "_-."); i >= 0 {
+		code = code[:i]
+	}
+	if _, ok := translations[code]; !ok {
+		code = DefaultLang
+	}
+	Lang = code
+}
+
+// T returns the interface text for key in the current language, falling
+// back to English, then to the key itself so a missing entry is visible
+func T(key string) string {
+	if text, ok := translations[Lang][key]; ok {
+		return text
+	}
+	if text, ok := translations[DefaultLang][key]; ok {
+		return text
+	}
+	return key
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// formatVerb matches printf verbs, ignoring an escaped %%
+var formatVerb = regexp.MustCompile(`%[^%]`)
+
+func TestTranslationsMatchEnglish(t *testing.T) {
+	english := translations[DefaultLang]
+	for lang, texts := range translations {
+		for key, text := range texts {
+			want, ok := english[key]
+			if !ok {
+				t.Errorf("%s has %q, which English lacks", lang, key)
+				continue
+			}
+			if got, want := formatVerb.FindAllString(text, -1), formatVerb.FindAllString(want, -1); !slices.Equal(got, want) {
+				t.Errorf("%s %q uses verbs %q, English uses %q", lang, key, got, want)
+			}
+		}
+	}
+}
+
+func TestSetLang(t *testing.T) {
+	defer SetLang("")
+
+	SetLang("de_DE.UTF-8")
+	if Lang != "de" || T("timer.complete.title") != "Dein Schwur ist gehalten." {
+		t.Errorf("de_DE.UTF-8: Lang = %q, title %q", Lang, T("timer.complete.title"))
+	}
+
+	delete(translations["de"], "menu.quit")
+	defer func() { translations["de"]["menu.quit"] = "Beenden" }()
+	if got := T("menu.quit"); got != "Quit" {
+		t.Errorf("missing German text should fall back to English, got %q", got)
+	}
+
+	SetLang("xx")
+	if Lang != DefaultLang {
+		t.Errorf("unknown language: Lang = %q, want %q", Lang, DefaultLang)
+	}
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// iconColumnWidth is the number of cells an icon column occupies, including
+// the trailing gap. Emoji are two cells wide, so one cell is left as padding.
+const iconColumnWidth = 3
+
+// maxIconWidth is the widest icon, in terminal cells, that keeps lists aligned
+const maxIconWidth = 2
+
+// validateIcon checks that icon is a single symbol narrow enough for the
+// icon column. ZWJ sequences and skin-tone modifiers count as one symbol.
+func validateIcon(icon string) error {
+	if uniseg.GraphemeClusterCount(icon) != 1 {
+		return errors.New("icon must be a single emoji or symbol")
+	}
+	if runewidth.StringWidth(icon) > maxIconWidth {
+		return errors.New("icon is too wide to line up in lists")
+	}
+	return nil
+}
+
+// renderIcon pads icon to the icon column using its display width rather
+// than its length, so multi-codepoint emoji stay aligned
+func renderIcon(icon string) string {
+	width := runewidth.StringWidth(icon)
+	if width >= iconColumnWidth {
+		return icon + " "
+	}
+	return icon + strings.Repeat(" ", iconColumnWidth-width)
+}
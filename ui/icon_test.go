@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestValidateIcon(t *testing.T) {
+	tests := []struct {
+		icon string
+		ok   bool
+	}{
+		{"🔷", true},
+		{"👍🏽", true},      // skin-tone modifier
+		{"👩‍💻", true},     // ZWJ sequence
+		{"🏴󠁧󠁢󠁥󠁮󠁧󠁿", true}, // tag sequence
+		{"A", true},
+		{"", false},
+		{"🔷🔷", false},
+		{"Go", false},
+	}
+
+	for _, tt := range tests {
+		if err := validateIcon(tt.icon); (err == nil) != tt.ok {
+			t.Errorf("validateIcon(%q) = %v, want ok=%v", tt.icon, err, tt.ok)
+		}
+	}
+}
+
+func TestRenderIconPadsToColumn(t *testing.T) {
+	for _, icon := range []string{"🔷", "👩‍💻", "A"} {
+		if w := runewidth.StringWidth(renderIcon(icon)); w != iconColumnWidth {
+			t.Errorf("renderIcon(%q) width = %d, want %d", icon, w, iconColumnWidth)
+		}
+	}
+}
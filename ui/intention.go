@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+// IntentionModel asks what the user vows to accomplish before the timer starts
+type IntentionModel struct {
+	subject   db.Subject
+	input     textinput.Model
+	tagsInput textinput.Model
+	onTags    bool // Focus is on the tags field
+}
+
+// IntentionSetMsg starts the session once the vow is spoken (or skipped)
+type IntentionSetMsg struct {
+	Subject   db.Subject
+	Intention string   // Empty when skipped
+	Tags      []string // Normalized; nil when none were given
+}
+
+func NewIntentionModel(subject db.Subject) IntentionModel {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. Finish the parser tests"
+	ti.CharLimit = 120
+	ti.Width = 60
+	ti.Focus()
+
+	tags := textinput.New()
+	tags.Placeholder = "optional, comma separated, e.g. blog, novel"
+	tags.CharLimit = 80
+	tags.Width = 60
+
+	return IntentionModel{subject: subject, input: ti, tagsInput: tags}
+}
+
+func (m IntentionModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m IntentionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			return m, func() tea.Msg { return BackToMenuMsg{} }
+		case "tab", "shift+tab", "up", "down":
+			m.onTags = !m.onTags
+			if m.onTags {
+				m.input.Blur()
+				return m, m.tagsInput.Focus()
+			}
+			m.tagsInput.Blur()
+			return m, m.input.Focus()
+		case "enter":
+			set := IntentionSetMsg{
+				Subject:   m.subject,
+				Intention: strings.Join(strings.Fields(m.input.Value()), " "),
+				Tags:      db.ParseTags(m.tagsInput.Value()),
+			}
+			return m, func() tea.Msg { return set }
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.onTags {
+		m.tagsInput, cmd = m.tagsInput.Update(msg)
+	} else {
+		m.input, cmd = m.input.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m IntentionModel) View() string {
+	title := TitleStyle.Render("Speak Your Bēot")
+	subject := StatusStyle.Render(fmt.Sprintf("Subject: %s", m.subject.Name))
+	prompt := NormalStyle.Render("What do you vow to accomplish this session?")
+	tagsLabel := NormalStyle.Render("Tags")
+	if m.onTags {
+		tagsLabel = SelectedStyle.Render("Tags")
+	}
+	help := HelpStyle.Render("enter begin (leave empty to skip) • tab tags • esc back to menu")
+
+	return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n\n  %s\n\n  %s\n\n  %s\n\n  %s\n",
+		title, subject, prompt, m.input.View(), tagsLabel, m.tagsInput.View(), help)
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// jumpToLetter finds the next item after cursor whose first letter is key,
+// wrapping around, so repeated presses cycle through the matches. It
+// reports false when key is not a single letter or nothing matches.
+func jumpToLetter(items []string, cursor int, key string) (int, bool) {
+	r, size := utf8.DecodeRuneInString(key)
+	if size != len(key) || !unicode.IsLetter(r) {
+		return cursor, false
+	}
+	want := unicode.ToLower(r)
+
+	for step := 1; step <= len(items); step++ {
+		i := (cursor + step) % len(items)
+		if firstLetter(items[i]) == want {
+			return i, true
+		}
+	}
+	return cursor, false
+}
+
+// firstLetter returns the lower-cased first letter of s, skipping leading
+// quote marks, digits and the like
+func firstLetter(s string) rune {
+	s = strings.TrimLeftFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.ToLower(r)
+}
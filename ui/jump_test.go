@@ -0,0 +1,29 @@
+package ui
+
+import "testing"
+
+func TestJumpToLetter(t *testing.T) {
+	items := []string{"GoLang", "Music", "\"Make it work\"", "React", "music theory"}
+
+	tests := []struct {
+		cursor int
+		key    string
+		want   int
+		ok     bool
+	}{
+		{0, "m", 1, true},
+		{1, "m", 2, true}, // leading quote marks are skipped
+		{2, "m", 4, true}, // case-insensitive
+		{4, "m", 1, true}, // wraps around
+		{0, "g", 0, true}, // the only match is the current item
+		{0, "z", 0, false},
+		{0, "1", 0, false},
+		{0, "enter", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := jumpToLetter(items, tt.cursor, tt.key)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("jumpToLetter(%d, %q) = %d, %v; want %d, %v", tt.cursor, tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// reservedKeys are handled by the app before any view sees them
+var reservedKeys = []string{"?", "h", "ctrl+c", "ctrl+n"}
+
+// TimerKeyMap holds the remappable keys of the timer
+type TimerKeyMap struct {
+	Pause         key.Binding
+	Extend        key.Binding
+	SkipBreak     key.Binding
+	PrevQuote     key.Binding
+	NextQuote     key.Binding
+	Pin           key.Binding
+	Expand        key.Binding
+	SwitchSubject key.Binding
+	SwitchUp      key.Binding // Moves through the subject switcher's list
+	SwitchDown    key.Binding
+	Minimal       key.Binding
+	DeepFocus     key.Binding
+	Reset         key.Binding
+	Cancel        key.Binding
+	GiveUp        key.Binding
+}
+
+// MenuKeyMap holds the remappable keys of the main menu
+type MenuKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Select   key.Binding
+	Continue key.Binding
+	Quit     key.Binding
+}
+
+// DefaultTimerKeyMap returns the timer keys as shipped
+func DefaultTimerKeyMap() TimerKeyMap {
+	return TimerKeyMap{
+		Pause:         key.NewBinding(key.WithKeys(" "), key.WithHelp("", "pause/resume")),
+		Extend:        key.NewBinding(key.WithKeys("+", "="), key.WithHelp("", "add 5 minutes")),
+		SkipBreak:     key.NewBinding(key.WithKeys("s"), key.WithHelp("", "skip break")),
+		PrevQuote:     key.NewBinding(key.WithKeys("left"), key.WithHelp("", "page back through recent quotes")),
+		NextQuote:     key.NewBinding(key.WithKeys("right"), key.WithHelp("", "page forward through recent quotes")),
+		Pin:           key.NewBinding(key.WithKeys("p"), key.WithHelp("", "pin/unpin the current quote")),
+		Expand:        key.NewBinding(key.WithKeys("e"), key.WithHelp("", "show the whole quote (when truncated)")),
+		SwitchSubject: key.NewBinding(key.WithKeys("tab"), key.WithHelp("", "switch subject (time so far stays with the old one)")),
+		SwitchUp:      key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("", "move up the subject switcher")),
+		SwitchDown:    key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("", "move down the subject switcher")),
+		Minimal:       key.NewBinding(key.WithKeys("m"), key.WithHelp("", "minimal view (countdown only)")),
+		DeepFocus:     key.NewBinding(key.WithKeys("d"), key.WithHelp("", "deep focus (just the time, no quotes)")),
+		Reset:         key.NewBinding(key.WithKeys("r"), key.WithHelp("", "reset timer")),
+		Cancel:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("", "back out in the first 10s (nothing logged)")),
+		GiveUp:        key.NewBinding(key.WithKeys("q"), key.WithHelp("", "give up (logged as abandoned)")),
+	}
+}
+
+// DefaultMenuKeyMap returns the menu keys as shipped
+func DefaultMenuKeyMap() MenuKeyMap {
+	return MenuKeyMap{
+		Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("", "move cursor up")),
+		Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("", "move cursor down")),
+		Select:   key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("", "select item")),
+		Continue: key.NewBinding(key.WithKeys("c"), key.WithHelp("", "continue with the last subject")),
+		Quit:     key.NewBinding(key.WithKeys("q"), key.WithHelp("", "quit")),
+	}
+}
+
+// TimerKeys and MenuKeys are the keys in use, after any config overrides
+var (
+	TimerKeys = DefaultTimerKeyMap()
+	MenuKeys  = DefaultMenuKeyMap()
+)
+
+// actions names each binding as it is written in the config file, in help order
+func (k *TimerKeyMap) actions() []namedBinding {
+	return []namedBinding{
+		{"pause", &k.Pause}, {"extend", &k.Extend}, {"skip_break", &k.SkipBreak},
+		{"prev_quote", &k.PrevQuote}, {"next_quote", &k.NextQuote}, {"pin", &k.Pin},
+		{"expand", &k.Expand}, {"switch_subject", &k.SwitchSubject}, {"switch_up", &k.SwitchUp},
+		{"switch_down", &k.SwitchDown}, {"minimal", &k.Minimal},
+		{"deep_focus", &k.DeepFocus}, {"reset", &k.Reset}, {"cancel", &k.Cancel}, {"give_up", &k.GiveUp},
+	}
+}
+
+func (k *MenuKeyMap) actions() []namedBinding {
+	return []namedBinding{
+		{"up", &k.Up}, {"down", &k.Down}, {"select", &k.Select}, {"continue", &k.Continue}, {"quit", &k.Quit},
+	}
+}
+
+type namedBinding struct {
+	name    string
+	binding *key.Binding
+}
+
+// ApplyKeyOverrides remaps keys from the config file's [keys.timer] and
+// [keys.menu] tables, e.g. pause = ["p"]. Unknown views or actions, keys the
+// app reserves, and a key bound to two actions in one view are errors, and
+// leave the defaults in place.
+func ApplyKeyOverrides(overrides map[string]map[string][]string) error {
+	timer, menu := DefaultTimerKeyMap(), DefaultMenuKeyMap()
+	views := map[string][]namedBinding{"timer": timer.actions(), "menu": menu.actions()}
+
+	for view, remaps := range overrides {
+		actions, ok := views[view]
+		if !ok {
+			return fmt.Errorf("unknown key map %q (want timer or menu)", view)
+		}
+		for name, keys := range remaps {
+			i := indexOfAction(actions, name)
+			if i < 0 {
+				return fmt.Errorf("unknown %s action %q", view, name)
+			}
+			if len(keys) == 0 {
+				return fmt.Errorf("%s.%s has no keys", view, name)
+			}
+			for _, k := range keys {
+				for _, reserved := range reservedKeys {
+					if k == reserved {
+						return fmt.Errorf("%s.%s: %q is reserved", view, name, k)
+					}
+				}
+			}
+			actions[i].binding.SetKeys(keys...)
+		}
+		if err := checkDuplicateKeys(view, actions); err != nil {
+			return err
+		}
+	}
+
+	TimerKeys, MenuKeys = timer, menu
+	return nil
+}
+
+func indexOfAction(actions []namedBinding, name string) int {
+	for i, a := range actions {
+		if a.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkDuplicateKeys reports a key that would trigger two actions in one view
+func checkDuplicateKeys(view string, actions []namedBinding) error {
+	owner := map[string]string{}
+	for _, a := range actions {
+		for _, k := range a.binding.Keys() {
+			if other, taken := owner[k]; taken {
+				names := []string{other, a.name}
+				sort.Strings(names)
+				return fmt.Errorf("%s: %q is bound to both %s and %s", view, k, names[0], names[1])
+			}
+			owner[k] = a.name
+		}
+	}
+	return nil
+}
+
+// keyNames shows special keys the way the help text writes them
+var keyNames = map[string]string{" ": "space", "up": "↑", "down": "↓", "left": "←", "right": "→"}
+
+// keyLabel renders a binding's keys for help text, e.g. "↑/k"
+func keyLabel(b key.Binding) string {
+	labels := make([]string, len(b.Keys()))
+	for i, k := range b.Keys() {
+		if name, ok := keyNames[k]; ok {
+			k = name
+		}
+		labels[i] = k
+	}
+	return strings.Join(labels, "/")
+}
+
+// shortKey renders just a binding's first key, for the one-line hints
+func shortKey(b key.Binding) string {
+	if len(b.Keys()) == 0 {
+		return ""
+	}
+	if name, ok := keyNames[b.Keys()[0]]; ok {
+		return name
+	}
+	return b.Keys()[0]
+}
+
+// keyMapHelp returns the help overlay rows for views with a remappable key map
+func keyMapHelp(view View) ([]keyBinding, bool) {
+	var actions []namedBinding
+	switch view {
+	case TimerViewState:
+		actions = TimerKeys.actions()
+	case MenuViewState:
+		actions = MenuKeys.actions()
+	default:
+		return nil, false
+	}
+
+	rows := make([]keyBinding, len(actions))
+	for i, a := range actions {
+		rows[i] = keyBinding{keyLabel(*a.binding), a.binding.Help().Desc}
+	}
+	return rows, true
+}
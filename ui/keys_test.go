@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestApplyKeyOverrides(t *testing.T) {
+	defer ApplyKeyOverrides(nil)
+
+	err := ApplyKeyOverrides(map[string]map[string][]string{
+		"timer": {"pause": {"p"}, "pin": {"P"}},
+		"menu":  {"up": {"up", "e"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewTimerModel(25, "", "Go")
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if next.(TimerModel).running {
+		t.Error("p should pause once remapped")
+	}
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if !next.(TimerModel).running {
+		t.Error("space should no longer pause")
+	}
+	if !strings.Contains(m.renderTimer(), "p pause/resume") {
+		t.Error("help should show the remapped key")
+	}
+
+	menu := NewMenuModel()
+	menu.cursor = 1
+	next2, _ := menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if next2.(MenuModel).cursor != 0 {
+		t.Error("e should move the menu cursor up")
+	}
+}
+
+func TestApplyKeyOverridesRejects(t *testing.T) {
+	defer ApplyKeyOverrides(nil)
+
+	tests := []struct {
+		name      string
+		overrides map[string]map[string][]string
+		want      string
+	}{
+		{"unknown view", map[string]map[string][]string{"stats": {"quit": {"x"}}}, "unknown key map"},
+		{"unknown action", map[string]map[string][]string{"timer": {"snooze": {"z"}}}, "unknown timer action"},
+		{"no keys", map[string]map[string][]string{"timer": {"pause": {}}}, "has no keys"},
+		{"reserved", map[string]map[string][]string{"menu": {"quit": {"?"}}}, "reserved"},
+		{"clash", map[string]map[string][]string{"timer": {"pause": {"r"}}}, `"r" is bound to both pause and reset`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ApplyKeyOverrides(tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+			if TimerKeys.Pause.Keys()[0] != " " {
+				t.Error("a rejected config should leave the defaults in place")
+			}
+		})
+	}
+}
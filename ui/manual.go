@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+// manualTimeLayout is how the start time is typed on the log-session form
+const manualTimeLayout = "2006-01-02 15:04"
+
+// ManualEntryModel logs a completed session done away from the computer
+type ManualEntryModel struct {
+	subjects     []db.Subject
+	subject      int // Index of the chosen subject
+	minutesInput textinput.Model
+	startInput   textinput.Model
+	inputFocus   int    // 0 = subject, 1 = minutes, 2 = start time
+	formErr      string // Validation problem shown under the form
+	loaded       bool
+	err          error
+}
+
+// SessionLoggedMsg is sent once a manual session has been saved
+type SessionLoggedMsg struct {
+	Err error
+}
+
+type manualSubjectsLoadedMsg struct {
+	Subjects []db.Subject
+	Err      error
+}
+
+func NewManualEntryModel() ManualEntryModel {
+	mi := textinput.New()
+	mi.Placeholder = "25"
+	mi.CharLimit = 3
+	mi.Width = 6
+
+	si := textinput.New()
+	si.Placeholder = manualTimeLayout
+	si.CharLimit = len(manualTimeLayout)
+	si.Width = 20
+	si.SetValue(time.Now().Add(-time.Duration(db.DefaultDurations().Work) * time.Minute).Format(manualTimeLayout))
+
+	return ManualEntryModel{minutesInput: mi, startInput: si}
+}
+
+func (m ManualEntryModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		subjects, err := db.GetAllSubjects()
+		return manualSubjectsLoadedMsg{Subjects: subjects, Err: err}
+	}
+}
+
+func (m ManualEntryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case manualSubjectsLoadedMsg:
+		m.loaded = true
+		m.subjects, m.err = msg.Subjects, msg.Err
+		return m, nil
+
+	case SessionLoggedMsg:
+		// Success is handled by the app, which leaves this view; a failure
+		// stays on the form so nothing typed is lost
+		if msg.Err != nil {
+			m.formErr = "Could not save: " + msg.Err.Error()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return BackToMenuMsg{} }
+		case "tab", "down":
+			m.focusInput((m.inputFocus + 1) % 3)
+			return m, nil
+		case "shift+tab", "up":
+			m.focusInput((m.inputFocus + 2) % 3)
+			return m, nil
+		case "enter":
+			if m.inputFocus < 2 {
+				m.focusInput(m.inputFocus + 1)
+				return m, nil
+			}
+			return m.submit()
+		}
+
+		if m.inputFocus == 0 {
+			if n := len(m.subjects); n > 0 {
+				switch msg.String() {
+				case "right", "l", " ":
+					m.subject = (m.subject + 1) % n
+				case "left":
+					m.subject = (m.subject + n - 1) % n
+				}
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.inputFocus {
+	case 1:
+		m.minutesInput, cmd = m.minutesInput.Update(msg)
+	case 2:
+		m.startInput, cmd = m.startInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// focusInput moves focus to the given field; the subject row has no text input
+func (m *ManualEntryModel) focusInput(i int) {
+	m.minutesInput.Blur()
+	m.startInput.Blur()
+	m.inputFocus = i
+	switch i {
+	case 1:
+		m.minutesInput.Focus()
+	case 2:
+		m.startInput.Focus()
+	}
+}
+
+// submit validates the form and saves the session as completed
+func (m ManualEntryModel) submit() (tea.Model, tea.Cmd) {
+	if len(m.subjects) == 0 {
+		return m, nil
+	}
+
+	minutes, err := strconv.Atoi(strings.TrimSpace(m.minutesInput.Value()))
+	if err != nil {
+		m.formErr = "Duration must be a number of minutes"
+		m.focusInput(1)
+		return m, nil
+	}
+	startedAt, err := time.ParseInLocation(manualTimeLayout, strings.TrimSpace(m.startInput.Value()), time.Local)
+	if err != nil {
+		m.formErr = "Start time must look like " + manualTimeLayout
+		m.focusInput(2)
+		return m, nil
+	}
+	if err := db.ValidateManualSession(minutes, startedAt, time.Now()); err != nil {
+		m.formErr = err.Error()
+		var invalid *db.ValidationError
+		if errors.As(err, &invalid) && invalid.Field == "duration" {
+			m.focusInput(1)
+		} else {
+			m.focusInput(2)
+		}
+		return m, nil
+	}
+	m.formErr = ""
+
+	subject := m.subjects[m.subject]
+	return m, func() tea.Msg {
+		// Dated to when it happened, so streaks and today's totals put it on the right day
+		endedAt := startedAt.Add(time.Duration(minutes) * time.Minute)
+		_, err := db.CreateSessionWithDetails(subject.ID, subject.Name, minutes, db.StatusCompleted, startedAt, db.SessionDetails{EndedAt: endedAt})
+		return SessionLoggedMsg{Err: err}
+	}
+}
+
+func (m ManualEntryModel) View() string {
+	title := TitleStyle.Render("✍ Log a Past Session")
+	help := HelpStyle.Render("tab/↑/↓ switch field • ←/→ change subject • enter save • esc back")
+
+	if m.err != nil {
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, ErrorStyle.Render("Error: "+m.err.Error()), help)
+	}
+	if !m.loaded {
+		return fmt.Sprintf("\n  %s\n\n  %s\n", title, NormalStyle.Render("Loading..."))
+	}
+	if len(m.subjects) == 0 {
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title,
+			NormalStyle.Render("No subjects yet. Add one from Start Focus Session first."),
+			HelpStyle.Render("esc back to menu"))
+	}
+
+	labels := []string{"Subject", "Minutes", "Started at"}
+	for i, label := range labels {
+		labels[i] = NormalStyle.Render(fmt.Sprintf("%-12s", label))
+		if i == m.inputFocus {
+			labels[i] = SelectedStyle.Render(fmt.Sprintf("%-12s", label))
+		}
+	}
+
+	subject := m.subjects[m.subject]
+	form := fmt.Sprintf("  %s ◂ %s%s ▸\n  %s %s\n  %s %s\n",
+		labels[0], renderIcon(subject.Icon), subject.Name,
+		labels[1], m.minutesInput.View(),
+		labels[2], m.startInput.View(),
+	)
+
+	status := ""
+	if m.formErr != "" {
+		status = "\n  " + WarningStyle.Render(m.formErr) + "\n"
+	}
+
+	return fmt.Sprintf("\n  %s\n\n  %s\n\n%s%s\n  %s\n", title,
+		NormalStyle.Render("Focused away from the keyboard? Log it so it counts."),
+		form, status, help)
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"Beot/db"
+)
+
+func TestManualSessionKeepsItsDay(t *testing.T) {
+	useTempStore(t)
+
+	subject, err := db.AddSubject("Go", "🐹")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Yesterday at 10:00, logged today
+	now := time.Now()
+	started := time.Date(now.Year(), now.Month(), now.Day()-1, 10, 0, 0, 0, time.Local)
+	m := NewManualEntryModel()
+	next, _ := m.Update(manualSubjectsLoadedMsg{Subjects: []db.Subject{*subject}})
+	m = next.(ManualEntryModel)
+	m.minutesInput.SetValue("30")
+	m.startInput.SetValue(started.Format(manualTimeLayout))
+
+	next, cmd := m.submit()
+	if cmd == nil {
+		t.Fatalf("the form should save, got %q", next.(ManualEntryModel).formErr)
+	}
+	if msg := cmd().(SessionLoggedMsg); msg.Err != nil {
+		t.Fatal(msg.Err)
+	}
+
+	sessions, _ := db.GetRecentSessions(1)
+	if want := started.Add(30 * time.Minute); !sessions[0].CompletedAt.Equal(want) {
+		t.Errorf("CompletedAt = %v, want %v", sessions[0].CompletedAt, want)
+	}
+	stats, _ := db.GetSessionStats()
+	if stats.CurrentStreak != 1 || stats.LongestStreak != 1 {
+		t.Errorf("streaks = %d/%d, want yesterday alone to count 1/1", stats.CurrentStreak, stats.LongestStreak)
+	}
+	if today, _ := db.GetCompletedCountToday(); today != 0 {
+		t.Errorf("a session logged for yesterday counted %d towards today", today)
+	}
+}
+
+func TestManualSaveErrorKeepsForm(t *testing.T) {
+	m := NewManualEntryModel()
+	next, _ := m.Update(manualSubjectsLoadedMsg{Subjects: []db.Subject{{Name: "Go"}}})
+	m = next.(ManualEntryModel)
+	m.minutesInput.SetValue("45")
+
+	next, _ = m.Update(SessionLoggedMsg{Err: errors.New("disk full")})
+	view := next.(ManualEntryModel).View()
+	if !strings.Contains(view, "disk full") || !strings.Contains(view, "45") {
+		t.Errorf("the error should show on the form with the typed values:\n%s", view)
+	}
+}
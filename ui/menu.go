@@ -3,7 +3,10 @@ package ui
 import (
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
 )
 
 // MenuChoice represents the menu options
@@ -11,9 +14,15 @@ type MenuChoice int
 
 const (
 	StartSession MenuChoice = iota
+	ContinueSession
 	ViewStats
+	ViewHistory
+	LogSession
 	ManageQuotes
+	BrowsePoems
 	ToggleDisplayMode
+	ToggleTheme
+	OpenSettings
 	QuitApp
 )
 
@@ -29,19 +38,66 @@ var Version = "dev"
 type MenuModel struct {
 	choices     []menuItem
 	cursor      int
-	streak      int         // We'll populate this later from the database
-	displayMode DisplayMode // Current display mode for timer
+	stats       db.SessionStats // Streak and totals for the footer
+	weekMinutes int             // Focus minutes this week, for the weekly goal
+	weeklyGoal  int             // Weekly target in minutes, 0 = none
+	today       int             // Focus minutes today
+	yesterday   int             // Focus minutes yesterday, compared against today
+	displayMode DisplayMode     // Current display mode for timer
+	quoteOfDay  *db.Quote       // Shown under the banner; nil when there are no quotes
+	saveErr     error           // Saving the last session failed
+}
+
+// QuoteOfTheDayMsg carries the quote shown on the menu today
+type QuoteOfTheDayMsg struct {
+	Quote *db.Quote
+	Err   error
+}
+
+// loadQuoteOfTheDayCmd fetches today's quote for the menu
+func loadQuoteOfTheDayCmd() tea.Cmd {
+	return func() tea.Msg {
+		q, err := db.GetQuoteOfTheDay()
+		return QuoteOfTheDayMsg{Quote: q, Err: err}
+	}
+}
+
+// LastSubjectMsg carries the subject of the most recent session, or nil when
+// there is no history or that subject has since been deleted
+type LastSubjectMsg struct {
+	Subject *db.Subject
+}
+
+// loadLastSubjectCmd looks up the subject used most recently
+func loadLastSubjectCmd() tea.Cmd {
+	return func() tea.Msg {
+		sessions, err := db.GetRecentSessions(1)
+		if err != nil || len(sessions) == 0 {
+			return LastSubjectMsg{}
+		}
+		subject, err := db.GetSubjectByID(sessions[0].SubjectID)
+		if err != nil {
+			return LastSubjectMsg{}
+		}
+		return LastSubjectMsg{Subject: subject}
+	}
 }
 
 // NewMenuModel creates a new menu
 func NewMenuModel() MenuModel {
 	return MenuModel{
 		choices: []menuItem{
-			{icon: "🎯", text: "Start Focus Session"},
-			{icon: "📜", text: "View Statistics"},
-			{icon: "💬", text: "Manage Quotes"},
-			{icon: "📖", text: "Display: Quotes"},
-			{icon: "🚪", text: "Quit"},
+			{icon: "🎯", text: T("menu.start")},
+			{icon: "↻", text: T("menu.continue")},
+			{icon: "📜", text: T("menu.stats")},
+			{icon: "🕰", text: T("menu.history")},
+			{icon: "✍", text: T("menu.log")},
+			{icon: "💬", text: T("menu.quotes")},
+			{icon: "📚", text: T("menu.poems")},
+			{icon: "📖", text: T("menu.display.quotes")},
+			{icon: "🎨", text: fmt.Sprintf(T("menu.theme"), ActiveTheme.Name)},
+			{icon: "⚙", text: T("menu.settings")},
+			{icon: "🚪", text: T("menu.quit")},
 		},
 		cursor:      0,
 		displayMode: DisplayModeQuotes,
@@ -53,18 +109,32 @@ func (m MenuModel) GetDisplayMode() DisplayMode {
 	return m.displayMode
 }
 
+// SetDisplayMode sets the display mode, e.g. from a saved preference
+func (m *MenuModel) SetDisplayMode(mode DisplayMode) {
+	m.displayMode = mode
+	m.updateDisplayModeText()
+}
+
 // updateDisplayModeText updates the menu item text for display mode
 func (m *MenuModel) updateDisplayModeText() {
-	if m.displayMode == DisplayModePoems {
-		m.choices[3] = menuItem{icon: "📖", text: "Display: Old English Poems"}
-	} else {
-		m.choices[3] = menuItem{icon: "💬", text: "Display: Quotes"}
+	switch m.displayMode {
+	case DisplayModePoems:
+		m.choices[ToggleDisplayMode] = menuItem{icon: "📖", text: T("menu.display.poems")}
+	case DisplayModeBoth:
+		m.choices[ToggleDisplayMode] = menuItem{icon: "📜", text: T("menu.display.both")}
+	default:
+		m.choices[ToggleDisplayMode] = menuItem{icon: "💬", text: T("menu.display.quotes")}
 	}
 }
 
-// SetStreak updates the streak display
-func (m *MenuModel) SetStreak(s int) {
-	m.streak = s
+// SetQuoteOfTheDay sets the quote shown under the banner, or hides it when nil
+func (m *MenuModel) SetQuoteOfTheDay(q *db.Quote) {
+	m.quoteOfDay = q
+}
+
+// SetStats updates the streak and totals shown in the footer
+func (m *MenuModel) SetStats(s db.SessionStats) {
+	m.stats = s
 }
 
 func (m MenuModel) Init() tea.Cmd {
@@ -74,41 +144,99 @@ func (m MenuModel) Init() tea.Cmd {
 func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k":
+		switch {
+		case key.Matches(msg, MenuKeys.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
-		case "down", "j":
+		case key.Matches(msg, MenuKeys.Down):
 			if m.cursor < len(m.choices)-1 {
 				m.cursor++
 			}
-		case "enter", " ":
+		case key.Matches(msg, MenuKeys.Select):
 			// Handle display mode toggle locally
 			if MenuChoice(m.cursor) == ToggleDisplayMode {
-				if m.displayMode == DisplayModeQuotes {
-					m.displayMode = DisplayModePoems
-				} else {
-					m.displayMode = DisplayModeQuotes
-				}
+				m.displayMode = m.displayMode.Next()
 				m.updateDisplayModeText()
+				return m, saveDisplayModeCmd(m.displayMode)
+			}
+			// Cycle themes locally; styles are rebuilt on the next render
+			if MenuChoice(m.cursor) == ToggleTheme {
+				m.choices[ToggleTheme] = menuItem{icon: "🎨", text: fmt.Sprintf(T("menu.theme"), NextTheme())}
 				return m, nil
 			}
 			// Send a message about what was selected
 			return m, func() tea.Msg {
 				return MenuSelectionMsg(m.cursor)
 			}
-		case "q", "ctrl+c":
+		case key.Matches(msg, MenuKeys.Continue):
+			return m, func() tea.Msg { return MenuSelectionMsg(ContinueSession) }
+		case msg.String() == "ctrl+c", key.Matches(msg, MenuKeys.Quit):
 			return m, tea.Quit
 		}
 	}
 	return m, nil
 }
 
+// SetSaveError shows why the last session could not be saved; nil clears it
+func (m *MenuModel) SetSaveError(err error) {
+	m.saveErr = err
+}
+
+// SetWeeklyProgress updates the weekly goal shown in the footer
+func (m *MenuModel) SetWeeklyProgress(minutes, goal int) {
+	m.weekMinutes, m.weeklyGoal = minutes, goal
+}
+
+// SetDayComparison updates today's focus time and the day before it
+func (m *MenuModel) SetDayComparison(today, yesterday int) {
+	m.today, m.yesterday = today, yesterday
+}
+
+// renderDayComparison shows today's focus time against yesterday's, e.g.
+// "Today 40m (▲ +15m vs yesterday)". Without a yesterday to beat it is just
+// today's total.
+func renderDayComparison(today, yesterday int) string {
+	text := HelpStyle.Render(fmt.Sprintf(T("menu.today"), formatMinutes(today)))
+	if yesterday == 0 {
+		return text
+	}
+
+	var change string
+	switch diff := today - yesterday; {
+	case diff > 0:
+		change = SuccessStyle.Render("▲ +" + formatMinutes(diff))
+	case diff < 0:
+		change = ErrorStyle.Render("▼ −" + formatMinutes(-diff))
+	default:
+		return text + HelpStyle.Render(" ("+T("menu.yesterday.same")+")")
+	}
+	return text + HelpStyle.Render(" (") + fmt.Sprintf(T("menu.yesterday"), change) + HelpStyle.Render(")")
+}
+
+// formatTotals summarises lifetime progress, e.g. "42 sessions · 17h"
+func formatTotals(s db.SessionStats) string {
+	noun := "sessions"
+	if s.CompletedSessions == 1 {
+		noun = "session"
+	}
+	focused := fmt.Sprintf("%dm", s.TotalMinutes)
+	if s.TotalMinutes >= 60 {
+		focused = fmt.Sprintf("%dh", s.TotalMinutes/60)
+	}
+	return fmt.Sprintf("%d %s · %s", s.CompletedSessions, noun, focused)
+}
+
 func (m MenuModel) View() string {
 	// Title banner and version
 	title := RenderBanner()
 	version := VersionStyle.Render("v" + Version)
+	if profile := db.ProfileName(); profile != "" {
+		version += VersionStyle.Render(" · " + profile)
+	}
+	if m.quoteOfDay != nil {
+		version += "\n\n" + RenderQuote(m.quoteOfDay.Text, m.quoteOfDay.Source)
+	}
 
 	// Menu items
 	var items string
@@ -126,16 +254,37 @@ func (m MenuModel) View() string {
 	}
 
 	// Streak display (moved to bottom)
-	streakText := HelpStyle.Render("Start a session to begin your streak!")
-	if m.streak > 0 {
-		streakText = StreakStyle.Render(fmt.Sprintf("⚡ %d day streak", m.streak))
+	streakText := HelpStyle.Render(T("menu.streak.none"))
+	if m.stats.CurrentStreak > 0 {
+		streakText = StreakStyle.Render(fmt.Sprintf(T("menu.streak"), m.stats.CurrentStreak))
+	}
+	if m.stats.CompletedSessions > 0 {
+		totals := formatTotals(m.stats)
+		if m.weeklyGoal > 0 {
+			totals += fmt.Sprintf(" · %d%% of weekly goal", m.weekMinutes*100/m.weeklyGoal)
+		}
+		streakText += "\n  " + HelpStyle.Render(totals)
+	}
+	if m.today > 0 || m.yesterday > 0 {
+		streakText += "\n  " + renderDayComparison(m.today, m.yesterday)
+	}
+	if m.saveErr != nil {
+		streakText += "\n  " + ErrorStyle.Render(saveErrText(m.saveErr))
 	}
 
 	// Help
-	help := HelpStyle.Render("↑/↓ navigate • enter select • q quit")
+	help := HelpStyle.Render(fmt.Sprintf(T("menu.help"), shortKey(MenuKeys.Up)+"/"+shortKey(MenuKeys.Down), shortKey(MenuKeys.Select), shortKey(MenuKeys.Continue), shortKey(MenuKeys.Quit)))
 
 	return fmt.Sprintf("\n%s\n  %s\n\n%s\n  %s\n\n  %s\n", title, version, items, streakText, help)
 }
 
+// saveDisplayModeCmd persists the display mode preference
+func saveDisplayModeCmd(mode DisplayMode) tea.Cmd {
+	return func() tea.Msg {
+		db.SetSetting(db.SettingDisplayMode, mode.String())
+		return nil
+	}
+}
+
 // MenuSelectionMsg is sent when a menu item is selected
 type MenuSelectionMsg int
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"Beot/db"
+)
+
+func TestContinueLastSubject(t *testing.T) {
+	useTempStore(t)
+
+	// With no history it falls back to the subject selector
+	next, _ := NewAppModel().Update(loadLastSubjectCmd()())
+	if view := next.(AppModel).currentView; view != SubjectSelectViewState {
+		t.Fatalf("no history: view = %v, want SubjectSelectViewState", view)
+	}
+
+	subject, err := db.AddSubjectWithDuration("Latin", "📜", 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.CreateSession(subject.ID, subject.Name, 40, db.StatusCompleted, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	next, cmd := NewAppModel().Update(loadLastSubjectCmd()())
+	if cmd == nil {
+		t.Fatal("continuing should start a session")
+	}
+	next, _ = next.(AppModel).Update(cmd())
+	m := next.(AppModel)
+	if m.currentView != TimerViewState || m.timer.subjectName != "Latin" || m.timer.totalSeconds != 40*60 {
+		t.Errorf("view = %v, timer for %q (%ds); want a 40 minute Latin timer", m.currentView, m.timer.subjectName, m.timer.totalSeconds)
+	}
+}
+
+func TestSessionFromCLIUsesSavedDisplay(t *testing.T) {
+	useTempStore(t)
+	if err := db.SetSetting(db.SettingDisplayMode, DisplayModePoems.String()); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetBoolSetting(db.SettingFavoritesOnly, true); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewAppModelWithSession(db.Subject{Name: "Latin"}, 25)
+	if m.timer.displayMode != DisplayModePoems || !m.timer.favoritesOnly {
+		t.Errorf("timer display %v, favorites only %v; want the saved poems and true", m.timer.displayMode, m.timer.favoritesOnly)
+	}
+}
+
+func TestSessionFromCLIUsesSavedDuration(t *testing.T) {
+	useTempStore(t)
+	if err := db.SetDurations(50, 10, 20, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewAppModelWithSession(db.Subject{Name: "Latin"}, 0)
+	if m.timer.totalSeconds != 50*60 || m.timer.durations.ShortBreak != 10 {
+		t.Errorf("timer %ds with %+v; want the saved 50 minute block and breaks", m.timer.totalSeconds, m.timer.durations)
+	}
+}
+
+func TestRenderDayComparison(t *testing.T) {
+	tests := []struct {
+		today, yesterday int
+		want             string
+	}{
+		{40, 0, "Today 40m"},
+		{40, 25, "Today 40m (▲ +15m vs yesterday)"},
+		{20, 90, "Today 20m (▼ −1h 10m vs yesterday)"},
+		{30, 30, "Today 30m (same as yesterday)"},
+	}
+	for _, tt := range tests {
+		if got := renderDayComparison(tt.today, tt.yesterday); got != tt.want {
+			t.Errorf("renderDayComparison(%d, %d) = %q, want %q", tt.today, tt.yesterday, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import "fmt"
+
+// focusMilestones are lifetime focus totals, in hours, that earn a celebration
+var focusMilestones = []int{10, 50, 100, 250, 500, 1000}
+
+// crossedMilestone returns the highest milestone passed when the lifetime
+// total moved from before to after minutes
+func crossedMilestone(before, after int) (hours int, ok bool) {
+	for _, h := range focusMilestones {
+		if before < h*60 && after >= h*60 {
+			hours, ok = h, true
+		}
+	}
+	return hours, ok
+}
+
+// renderMilestone is the celebratory completion screen shown instead of the
+// usual one when a session crosses a milestone or sets a new longest block.
+// Either hours or recordMinutes may be zero.
+func renderMilestone(hours, recordMinutes int, subjectName string) string {
+	var title, message string
+	if hours > 0 {
+		title = SuccessStyle.Render(fmt.Sprintf("%d hours of kept vows!", hours))
+		message = NormalStyle.Render(fmt.Sprintf(
+			"With this %s session your lifetime focus has passed %d hours.\nThe scops will sing of this.",
+			subjectName, hours,
+		))
+		if recordMinutes > 0 {
+			message += "\n\n" + WarningStyle.Render(fmt.Sprintf("New record! %d minutes is your longest block yet.", recordMinutes))
+		}
+	} else {
+		title = SuccessStyle.Render("New record!")
+		message = NormalStyle.Render(fmt.Sprintf(
+			"%d minutes of %s — your longest focus block yet.\nYou have outdone your own deeds.",
+			recordMinutes, subjectName,
+		))
+	}
+
+	content := fmt.Sprintf(
+		"%s\n\n%s\n\n%s",
+		title,
+		message,
+		HelpStyle.Render("Press any key to continue"),
+	)
+
+	return fmt.Sprintf("\n%s\n\n%s\n", RenderBanner(), BoxStyle.Render(content))
+}
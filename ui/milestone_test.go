@@ -0,0 +1,24 @@
+package ui
+
+import "testing"
+
+func TestCrossedMilestone(t *testing.T) {
+	tests := []struct {
+		before, after int
+		want          int
+		ok            bool
+	}{
+		{0, 25, 0, false},
+		{590, 615, 10, true},
+		{600, 625, 0, false}, // already past 10h
+		{2990, 3010, 50, true},
+		{580, 3100, 50, true}, // highest crossed wins
+	}
+
+	for _, tt := range tests {
+		got, ok := crossedMilestone(tt.before, tt.after)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("crossedMilestone(%d, %d) = %d, %v; want %d, %v", tt.before, tt.after, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+// poemPageSize is how many poems are listed at once above the detail pane
+const poemPageSize = 8
+
+// PoemsModel browses the Old English poems and edits them in place
+type PoemsModel struct {
+	all         []db.Poem // every loaded poem, before the source filter
+	poems       []db.Poem // poems matching the filter
+	cursor      int
+	offset      int // first visible row
+	filtering   bool
+	filterInput textinput.Model
+	loaded      bool
+	err         error
+
+	// Edit form for the poem under the cursor
+	editing      bool
+	oldInput     textarea.Model // Multi-line; ctrl+j or alt+enter inserts a line break
+	modernInput  textarea.Model
+	sourceInput  textinput.Model
+	lineRefInput textinput.Model
+	editFocus    int    // 0 = Old English, 1 = modern English, 2 = source, 3 = line reference
+	formErr      string // Validation problem shown on the edit form
+}
+
+// poemFieldCount is the number of fields on the edit form
+const poemFieldCount = 4
+
+type PoemsLoadedMsg struct {
+	Poems []db.Poem
+	Err   error
+}
+
+// PoemUpdatedMsg reports an edit being saved, with the poem as stored
+type PoemUpdatedMsg struct {
+	Poem db.Poem
+	Err  error
+}
+
+func NewPoemsModel() PoemsModel {
+	fi := textinput.New()
+	fi.Placeholder = "Source, e.g. Beowulf"
+	fi.Prompt = "/ "
+	fi.CharLimit = 50
+	fi.Width = 30
+
+	si := textinput.New()
+	si.Placeholder = "Source, e.g. Beowulf"
+	si.CharLimit = 100
+	si.Width = 40
+
+	li := textinput.New()
+	li.Placeholder = "Lines (optional), e.g. 1-3"
+	li.CharLimit = 30
+	li.Width = 20
+
+	return PoemsModel{
+		filterInput:  fi,
+		oldInput:     newPoemTextarea("Old English text..."),
+		modernInput:  newPoemTextarea("Modern English translation..."),
+		sourceInput:  si,
+		lineRefInput: li,
+	}
+}
+
+// newPoemTextarea returns a multi-line field for one language of a poem
+func newPoemTextarea(placeholder string) textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = placeholder
+	ta.ShowLineNumbers = false
+	ta.SetWidth(60)
+	ta.SetHeight(4)
+	// Enter moves to the next field, so line breaks need their own keys
+	ta.KeyMap.InsertNewline = key.NewBinding(key.WithKeys("ctrl+j", "alt+enter"))
+	return ta
+}
+
+func (m *PoemsModel) LoadPoems() tea.Cmd {
+	return func() tea.Msg {
+		poems, err := db.GetAllPoems()
+		return PoemsLoadedMsg{Poems: poems, Err: err}
+	}
+}
+
+func (m PoemsModel) Init() tea.Cmd {
+	return m.LoadPoems()
+}
+
+func (m PoemsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case PoemsLoadedMsg:
+		m.loaded = true
+		m.err = msg.Err
+		m.all = msg.Poems
+		m.applyFilter()
+		return m, nil
+
+	case PoemUpdatedMsg:
+		var invalid *db.ValidationError
+		switch {
+		case errors.As(msg.Err, &invalid):
+			m.formErr = invalid.Error()
+		case msg.Err != nil:
+			m.formErr = "Could not save: " + msg.Err.Error()
+		default:
+			// Replaced in place, so the cursor and filter stay put
+			m.all = replacePoem(m.all, msg.Poem)
+			m.poems = replacePoem(m.poems, msg.Poem)
+			m.editing = false
+			m.formErr = ""
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m.handleEditInput(msg)
+		}
+		if m.filtering {
+			switch msg.String() {
+			case "enter":
+				m.filtering = false
+				m.filterInput.Blur()
+				return m, nil
+			case "esc":
+				m.filtering = false
+				m.filterInput.Blur()
+				m.filterInput.Reset()
+				m.applyFilter()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.filterInput, cmd = m.filterInput.Update(msg)
+			m.applyFilter()
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "esc":
+			// The first esc clears an active filter
+			if m.filterInput.Value() != "" {
+				m.filterInput.Reset()
+				m.applyFilter()
+				return m, nil
+			}
+			return m, func() tea.Msg { return BackToMenuMsg{} }
+		case "q":
+			return m, func() tea.Msg { return BackToMenuMsg{} }
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				m.scrollToCursor()
+			}
+		case "down", "j":
+			if m.cursor < len(m.poems)-1 {
+				m.cursor++
+				m.scrollToCursor()
+			}
+		case "/":
+			m.filtering = true
+			return m, m.filterInput.Focus()
+		case "e":
+			if len(m.poems) > 0 {
+				return m.startEdit()
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// startEdit opens the edit form filled in from the poem under the cursor
+func (m PoemsModel) startEdit() (tea.Model, tea.Cmd) {
+	p := m.poems[m.cursor]
+	m.oldInput.SetValue(p.OldEnglish)
+	m.modernInput.SetValue(p.ModernEnglish)
+	m.sourceInput.SetValue(p.Source)
+	m.lineRefInput.SetValue(p.LineRef)
+	m.editing = true
+	m.formErr = ""
+	return m, m.focusEditField(0)
+}
+
+// focusEditField moves the cursor to field i of the edit form
+func (m *PoemsModel) focusEditField(i int) tea.Cmd {
+	m.editFocus = i
+	m.oldInput.Blur()
+	m.modernInput.Blur()
+	m.sourceInput.Blur()
+	m.lineRefInput.Blur()
+	switch i {
+	case 0:
+		return m.oldInput.Focus()
+	case 1:
+		return m.modernInput.Focus()
+	case 2:
+		return m.sourceInput.Focus()
+	default:
+		return m.lineRefInput.Focus()
+	}
+}
+
+func (m PoemsModel) handleEditInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editing = false
+		m.formErr = ""
+		return m, nil
+	case "tab":
+		return m, m.focusEditField((m.editFocus + 1) % poemFieldCount)
+	case "shift+tab":
+		return m, m.focusEditField((m.editFocus + poemFieldCount - 1) % poemFieldCount)
+	case "enter":
+		if m.editFocus < poemFieldCount-1 {
+			return m, m.focusEditField(m.editFocus + 1)
+		}
+		// The db layer validates and reports problems back as PoemUpdatedMsg
+		p := m.poems[m.cursor]
+		oldEnglish, modernEnglish := m.oldInput.Value(), m.modernInput.Value()
+		source, lineRef := m.sourceInput.Value(), m.lineRefInput.Value()
+		return m, func() tea.Msg {
+			err := db.UpdatePoem(p.ID, oldEnglish, modernEnglish, source, lineRef)
+			if err != nil {
+				return PoemUpdatedMsg{Err: err}
+			}
+			// Store the text as the db layer normalized it
+			p.OldEnglish, p.ModernEnglish, p.Source, p.LineRef, _ = db.ValidatePoem(oldEnglish, modernEnglish, source, lineRef)
+			return PoemUpdatedMsg{Poem: p}
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.editFocus {
+	case 0:
+		m.oldInput, cmd = m.oldInput.Update(msg)
+	case 1:
+		m.modernInput, cmd = m.modernInput.Update(msg)
+	case 2:
+		m.sourceInput, cmd = m.sourceInput.Update(msg)
+	default:
+		m.lineRefInput, cmd = m.lineRefInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// replacePoem returns a copy of poems with the entry sharing p's ID swapped
+// for p, leaving the previous model's slice untouched
+func replacePoem(poems []db.Poem, p db.Poem) []db.Poem {
+	out := make([]db.Poem, len(poems))
+	copy(out, poems)
+	for i := range out {
+		if out[i].ID == p.ID {
+			out[i] = p
+		}
+	}
+	return out
+}
+
+// applyFilter keeps the poems whose source contains the filter text,
+// ignoring case, and moves the cursor back to the top
+func (m *PoemsModel) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(m.filterInput.Value()))
+	m.cursor, m.offset = 0, 0
+	if query == "" {
+		m.poems = m.all
+		return
+	}
+	m.poems = nil
+	for _, p := range m.all {
+		if strings.Contains(strings.ToLower(p.Source), query) {
+			m.poems = append(m.poems, p)
+		}
+	}
+}
+
+// scrollToCursor keeps the cursor inside the visible window
+func (m *PoemsModel) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+poemPageSize {
+		m.offset = m.cursor - poemPageSize + 1
+	}
+}
+
+func (m PoemsModel) View() string {
+	title := TitleStyle.Render("📚 Browse Poems")
+
+	if m.err != nil {
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n",
+			title,
+			ErrorStyle.Render("Error: "+m.err.Error()),
+			HelpStyle.Render("esc/q back to menu"),
+		)
+	}
+
+	if !m.loaded {
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n",
+			title,
+			NormalStyle.Render("Loading..."),
+			HelpStyle.Render("esc/q back to menu"),
+		)
+	}
+
+	if m.editing {
+		return m.renderEditForm(title)
+	}
+
+	if len(m.all) == 0 {
+		empty := NormalStyle.Render("No poems yet. Run the seed command to add some.")
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, empty, HelpStyle.Render("esc/q back to menu"))
+	}
+
+	filter := ""
+	if m.filtering || m.filterInput.Value() != "" {
+		filter = "  " + m.filterInput.View() + "\n\n"
+	}
+
+	help := HelpStyle.Render("↑/↓ scroll • e edit • / filter by source • esc/q back")
+	if m.filtering {
+		help = HelpStyle.Render("type to filter • enter done • esc clear")
+	} else if m.filterInput.Value() != "" {
+		help = HelpStyle.Render("↑/↓ scroll • e edit • / edit filter • esc clear filter • q back")
+	}
+
+	if len(m.poems) == 0 {
+		empty := NormalStyle.Render("No poems from a matching source.")
+		return fmt.Sprintf("\n  %s\n\n%s  %s\n\n  %s\n", title, filter, empty, help)
+	}
+
+	end := m.offset + poemPageSize
+	if end > len(m.poems) {
+		end = len(m.poems)
+	}
+
+	var list string
+	for i := m.offset; i < end; i++ {
+		p := m.poems[i]
+		cursor := "  "
+		style := NormalStyle
+		if i == m.cursor {
+			cursor = "▸ "
+			style = SelectedStyle
+		}
+
+		first := truncateText(strings.SplitN(p.ModernEnglish, "\n", 2)[0], 40)
+		label := p.Source
+		if p.LineRef != "" {
+			label += ", " + p.LineRef
+		}
+		list += fmt.Sprintf("%s%s  %s\n", cursor, style.Render(label), HelpStyle.Render(first))
+	}
+
+	position := HelpStyle.Render(fmt.Sprintf("%d of %d", m.cursor+1, len(m.poems)))
+	p := m.poems[m.cursor]
+	detail := RenderPoem(p.OldEnglish, p.ModernEnglish, p.Source, p.LineRef)
+
+	return fmt.Sprintf("\n  %s\n\n%s%s\n  %s\n\n%s\n\n  %s\n", title, filter, list, position, detail, help)
+}
+
+func (m PoemsModel) renderEditForm(title string) string {
+	form := fmt.Sprintf(
+		"Old English:\n%s\n\nModern English:\n%s\n\nSource:\n%s\n\nLines:\n%s",
+		m.oldInput.View(),
+		m.modernInput.View(),
+		m.sourceInput.View(),
+		m.lineRefInput.View(),
+	)
+
+	if m.formErr != "" {
+		form += "\n\n" + WarningStyle.Render(m.formErr)
+	}
+
+	help := HelpStyle.Render("tab/shift+tab switch field • ctrl+j new line • enter next/save • esc cancel")
+
+	return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, form, help)
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+func TestPoemsFilterBySource(t *testing.T) {
+	m := NewPoemsModel()
+	next, _ := m.Update(PoemsLoadedMsg{Poems: []db.Poem{
+		{Source: "Beowulf", LineRef: "1-3"},
+		{Source: "The Wanderer"},
+		{Source: "Beowulf", LineRef: "710-712"},
+	}})
+	m = next.(PoemsModel)
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'/'}},
+		{Type: tea.KeyRunes, Runes: []rune("beo")},
+		{Type: tea.KeyEnter},
+	} {
+		next, _ = m.Update(msg)
+		m = next.(PoemsModel)
+	}
+	if m.filtering || len(m.poems) != 2 {
+		t.Fatalf("after filtering: filtering %v, %d poems; want false, 2", m.filtering, len(m.poems))
+	}
+
+	// The first esc clears the filter rather than leaving
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(PoemsModel)
+	if cmd != nil || len(m.poems) != 3 {
+		t.Errorf("after esc: %d poems, cmd %v; want all 3 and no command", len(m.poems), cmd != nil)
+	}
+}
+
+func TestPoemsEdit(t *testing.T) {
+	store := useTempStore(t)
+
+	for _, ref := range []string{"1-3", "4-6"} {
+		if _, err := store.AddPoemWithSubjects("Hwæt", "Listen", "Beowulf", ref, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	poems, _ := db.GetAllPoems()
+	created := poems[1].CreatedAt
+
+	m := NewPoemsModel()
+	next, _ := m.Update(PoemsLoadedMsg{Poems: poems})
+	m = next.(PoemsModel)
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	next, _ = next.(PoemsModel).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = next.(PoemsModel)
+
+	if !m.editing || m.oldInput.Value() != "Hwæt" || m.modernInput.Value() != "Listen" ||
+		m.sourceInput.Value() != "Beowulf" || m.lineRefInput.Value() != "4-6" {
+		t.Fatalf("the form should be filled in from the poem, got %q %q %q %q",
+			m.oldInput.Value(), m.modernInput.Value(), m.sourceInput.Value(), m.lineRefInput.Value())
+	}
+
+	// Fix a missing thorn, then step through the fields to save
+	m.oldInput.SetValue("Hwæt! We Gardena\nþeodcyninga")
+	var cmd tea.Cmd
+	for range poemFieldCount {
+		next, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = next.(PoemsModel)
+	}
+	next, _ = m.Update(cmd())
+	m = next.(PoemsModel)
+
+	if m.editing || m.cursor != 1 {
+		t.Errorf("after saving: editing %v, cursor %d; want the list back on the same poem", m.editing, m.cursor)
+	}
+	if m.poems[1].OldEnglish != "Hwæt! We Gardena\nþeodcyninga" {
+		t.Errorf("the list should show the edit, got %q", m.poems[1].OldEnglish)
+	}
+	stored, _ := db.GetAllPoems()
+	if stored[1].OldEnglish != "Hwæt! We Gardena\nþeodcyninga" || !stored[1].CreatedAt.Equal(created) || stored[0].OldEnglish != "Hwæt" {
+		t.Errorf("only the edited poem should change, keeping its creation time: %+v", stored)
+	}
+
+	// An empty source is refused and the form stays open
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = next.(PoemsModel)
+	m.sourceInput.SetValue("  ")
+	m.editFocus = poemFieldCount - 1
+	next, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	next, _ = next.(PoemsModel).Update(cmd())
+	if m = next.(PoemsModel); !m.editing || m.formErr == "" {
+		t.Errorf("an empty source should keep the form open with an error, got editing %v, %q", m.editing, m.formErr)
+	}
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The countdown breathes while a block runs: its colour eases from Primary
+// towards Muted and back. The pulse has its own tick, so the countdown tick
+// is never delayed or doubled by it.
+const (
+	pulseInterval = 150 * time.Millisecond
+	pulseSteps    = 24  // Ticks per breath, about 3.6s
+	pulseDepth    = 0.4 // How far towards Muted the colour fades at the low point
+)
+
+// ReducedMotion turns the pulse off. It comes from Settings → Reduced
+// motion, or BEOT_REDUCED_MOTION=1 which always wins.
+var ReducedMotion, reducedMotionForced = reducedMotionFromEnv()
+
+func reducedMotionFromEnv() (on, set bool) {
+	on, err := strconv.ParseBool(os.Getenv("BEOT_REDUCED_MOTION"))
+	return on, err == nil
+}
+
+// SetReducedMotion applies the saved setting unless the environment has
+// already decided
+func SetReducedMotion(on bool) {
+	if !reducedMotionForced {
+		ReducedMotion = on
+	}
+}
+
+// pulseTickMsg advances the pulse of the chain with the same id
+type pulseTickMsg struct{ id int }
+
+// pulseChains hands out chain ids, so a chain left over from an earlier
+// timer or block never drives the current one
+var pulseChains int
+
+func newPulseID() int {
+	pulseChains++
+	return pulseChains
+}
+
+// pulsing reports whether the countdown breathes: only while it runs,
+// outside deep focus, and with motion allowed. When it stops the chain
+// ends, and restartPulse begins a new one.
+func (m TimerModel) pulsing() bool {
+	return !ReducedMotion && m.running && !m.deepFocus
+}
+
+// pulseCmd schedules the next pulse frame, or nothing while not pulsing
+func (m TimerModel) pulseCmd() tea.Cmd {
+	if !m.pulsing() {
+		return nil
+	}
+	id := m.pulseID
+	return tea.Tick(pulseInterval, func(time.Time) tea.Msg {
+		return pulseTickMsg{id: id}
+	})
+}
+
+// restartPulse starts a fresh chain, dropping any still in flight. Call it
+// whenever the countdown starts running again or leaves deep focus.
+func (m *TimerModel) restartPulse() tea.Cmd {
+	m.pulseID = newPulseID()
+	return m.pulseCmd()
+}
+
+// pulseColor is the countdown colour at a step of the breath
+func pulseColor(step int) lipgloss.Color {
+	from, ok := parseColor(Primary)
+	to, ok2 := parseColor(Muted)
+	if !ok || !ok2 {
+		return Primary
+	}
+	// 0 at the start of the breath, 1 halfway, easing in and out
+	t := (1 - math.Cos(2*math.Pi*float64(step%pulseSteps)/pulseSteps)) / 2
+	c := lerpRGB(from, to, t*pulseDepth)
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b))
+}
+
+// countdownStyle is TimerStyle, breathing while the countdown runs
+func (m TimerModel) countdownStyle() lipgloss.Style {
+	if !m.pulsing() {
+		return TimerStyle
+	}
+	return TimerStyle.Foreground(pulseColor(m.pulseStep))
+}
@@ -1,8 +1,12 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -13,17 +17,28 @@ type QuotesModel struct {
 	quotes      []db.Quote
 	cursor      int
 	adding      bool
-	textInput   textinput.Model
+	textInput   textarea.Model // Multi-line; ctrl+j or alt+enter inserts a line break
 	sourceInput textinput.Model
-	inputFocus  int // 0 = text, 1 = source
+	inputFocus  int    // 0 = text, 1 = source
+	formErr     string // Validation problem shown on the add form
+	// favoritesOnly mirrors the saved setting restricting timer rotation
+	favoritesOnly bool
+	// confirming is true while asking whether to delete the selected quote
+	confirming bool
+	// lastDeleted holds the most recently deleted quote so it can be restored with u
+	lastDeleted *db.Quote
 	err         error
 }
 
 func NewQuotesModel() QuotesModel {
-	ti := textinput.New()
+	ti := textarea.New()
 	ti.Placeholder = "Enter quote text..."
-	ti.CharLimit = 500
-	ti.Width = 60
+	ti.CharLimit = db.MaxQuoteLength
+	ti.ShowLineNumbers = false
+	ti.SetWidth(60)
+	ti.SetHeight(4)
+	// Enter moves to the source field, so line breaks need their own keys
+	ti.KeyMap.InsertNewline = key.NewBinding(key.WithKeys("ctrl+j", "alt+enter"))
 
 	si := textinput.New()
 	si.Placeholder = "Source (optional)"
@@ -57,9 +72,21 @@ type QuoteAddedMsg struct {
 }
 
 type QuoteDeletedMsg struct {
+	Quote db.Quote
+	Err   error
+}
+
+type QuoteRestoredMsg struct {
 	Err error
 }
 
+type QuoteFavoritedMsg struct {
+	Err error
+}
+
+// FavoritesOnlyChangedMsg is sent when the favorites-only rotation setting changes
+type FavoritesOnlyChangedMsg bool
+
 func (m QuotesModel) Init() tea.Cmd {
 	return m.LoadQuotes()
 }
@@ -75,11 +102,16 @@ func (m QuotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case QuoteAddedMsg:
-		if msg.Err != nil {
+		var invalid *db.ValidationError
+		switch {
+		case errors.As(msg.Err, &invalid):
+			m.formErr = invalid.Error()
+		case msg.Err != nil:
 			m.err = msg.Err
-		} else {
+		default:
 			m.quotes = append(m.quotes, *msg.Quote)
 			m.adding = false
+			m.formErr = ""
 			m.textInput.Reset()
 			m.sourceInput.Reset()
 		}
@@ -88,14 +120,40 @@ func (m QuotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case QuoteDeletedMsg:
 		if msg.Err != nil {
 			m.err = msg.Err
+		} else {
+			deleted := msg.Quote
+			m.lastDeleted = &deleted
 		}
 		return m, m.LoadQuotes()
 
+	case QuoteRestoredMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		}
+		return m, m.LoadQuotes()
+
+	case QuoteFavoritedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.adding {
 			return m.handleAddingInput(msg)
 		}
 
+		if m.confirming {
+			switch msg.String() {
+			case "y":
+				m.confirming = false
+				return m, m.deleteCurrentQuote()
+			case "n", "esc":
+				m.confirming = false
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "esc", "q":
 			return m, func() tea.Msg { return BackToMenuMsg{} }
@@ -109,13 +167,34 @@ func (m QuotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "a":
 			m.adding = true
-			m.textInput.Focus()
 			m.inputFocus = 0
-			return m, textinput.Blink
+			return m, m.textInput.Focus()
 		case "d", "delete":
 			if len(m.quotes) > 0 {
-				return m, m.deleteCurrentQuote()
+				m.confirming = true
 			}
+		case "u":
+			if m.lastDeleted != nil {
+				return m.restoreLastDeleted()
+			}
+		case "f":
+			if len(m.quotes) > 0 {
+				return m.toggleFavorite()
+			}
+		case "F":
+			m.favoritesOnly = !m.favoritesOnly
+			favoritesOnly := m.favoritesOnly
+			return m, func() tea.Msg {
+				db.SetBoolSetting(db.SettingFavoritesOnly, favoritesOnly)
+				return FavoritesOnlyChangedMsg(favoritesOnly)
+			}
+		default:
+			// Any other letter jumps to the next quote starting with it
+			texts := make([]string, len(m.quotes))
+			for i, q := range m.quotes {
+				texts[i] = q.Text
+			}
+			m.cursor, _ = jumpToLetter(texts, m.cursor, msg.String())
 		}
 	}
 
@@ -126,6 +205,7 @@ func (m QuotesModel) handleAddingInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
 		m.adding = false
+		m.formErr = ""
 		m.textInput.Reset()
 		m.sourceInput.Reset()
 		return m, nil
@@ -149,10 +229,8 @@ func (m QuotesModel) handleAddingInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		// Submit the quote
+		// The db layer validates and reports problems back as QuoteAddedMsg
 		text := m.textInput.Value()
-		if text == "" {
-			return m, nil
-		}
 		source := m.sourceInput.Value()
 		return m, func() tea.Msg {
 			quote, err := db.AddQuote(text, source)
@@ -170,14 +248,46 @@ func (m QuotesModel) handleAddingInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// toggleFavorite flips the favorite flag on the selected quote
+func (m QuotesModel) toggleFavorite() (tea.Model, tea.Cmd) {
+	if m.cursor >= len(m.quotes) {
+		return m, nil
+	}
+	// Copy so the previous model's slice isn't mutated
+	quotes := make([]db.Quote, len(m.quotes))
+	copy(quotes, m.quotes)
+	quotes[m.cursor].Favorite = !quotes[m.cursor].Favorite
+	m.quotes = quotes
+
+	id := quotes[m.cursor].ID
+	favorite := quotes[m.cursor].Favorite
+	return m, func() tea.Msg {
+		err := db.SetQuoteFavorite(id, favorite)
+		return QuoteFavoritedMsg{Err: err}
+	}
+}
+
 func (m QuotesModel) deleteCurrentQuote() tea.Cmd {
 	if m.cursor >= len(m.quotes) {
 		return nil
 	}
-	id := m.quotes[m.cursor].ID
+	quote := m.quotes[m.cursor]
 	return func() tea.Msg {
-		err := db.DeleteQuote(id)
-		return QuoteDeletedMsg{Err: err}
+		err := db.DeleteQuote(quote.ID)
+		return QuoteDeletedMsg{Quote: quote, Err: err}
+	}
+}
+
+// restoreLastDeleted re-inserts the most recently deleted quote
+func (m QuotesModel) restoreLastDeleted() (tea.Model, tea.Cmd) {
+	quote := *m.lastDeleted
+	m.lastDeleted = nil
+	return m, func() tea.Msg {
+		restored, err := db.AddQuoteWithSubjects(quote.Text, quote.Source, quote.Subjects)
+		if err == nil && quote.Favorite {
+			err = db.SetQuoteFavorite(restored.ID, true)
+		}
+		return QuoteRestoredMsg{Err: err}
 	}
 }
 
@@ -206,7 +316,11 @@ func (m QuotesModel) renderAddForm(title string) string {
 		m.sourceInput.View(),
 	)
 
-	help := HelpStyle.Render("tab switch field • enter next/submit • esc cancel")
+	if m.formErr != "" {
+		form += "\n\n" + WarningStyle.Render(m.formErr)
+	}
+
+	help := HelpStyle.Render("tab switch field • ctrl+j new line • enter next/submit • esc cancel")
 
 	return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, form, help)
 }
@@ -215,6 +329,9 @@ func (m QuotesModel) renderList(title string) string {
 	if len(m.quotes) == 0 {
 		empty := NormalStyle.Render("No quotes yet. Press 'a' to add one.")
 		help := HelpStyle.Render("a add • esc/q back to menu")
+		if m.lastDeleted != nil {
+			help = HelpStyle.Render("a add • u undo delete • esc/q back to menu")
+		}
 		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, empty, help)
 	}
 
@@ -227,19 +344,32 @@ func (m QuotesModel) renderList(title string) string {
 			style = SelectedStyle
 		}
 
-		text := q.Text
-		if len(text) > 50 {
-			text = text[:50] + "..."
-		}
+		// Show multi-line quotes on one row
+		text := truncateText(strings.ReplaceAll(q.Text, "\n", " / "), 50)
 		if q.Source != "" {
 			text += " — " + q.Source
 		}
-		list += fmt.Sprintf("%s%s\n", cursor, style.Render(text))
+		star := "  "
+		if q.Favorite {
+			star = StreakStyle.Render("★ ")
+		}
+		list += fmt.Sprintf("%s%s%s\n", cursor, star, style.Render(text))
+	}
+
+	rotation := HelpStyle.Render("Timer rotation: all quotes")
+	if m.favoritesOnly {
+		rotation = StreakStyle.Render("Timer rotation: ★ favorites only")
 	}
 
-	help := HelpStyle.Render("↑/↓ navigate • a add • d delete • esc/q back")
+	help := HelpStyle.Render("↑/↓ navigate • a add • d delete • f favorite • F favorites only • esc/q back")
+	if m.lastDeleted != nil {
+		help = HelpStyle.Render("↑/↓ navigate • a add • d delete • u undo delete • f favorite • F favorites only • esc/q back")
+	}
+	if m.confirming {
+		help = WarningStyle.Render("Delete this quote? [y] yes • [n] no")
+	}
 
-	return fmt.Sprintf("\n  %s\n\n%s\n  %s\n", title, list, help)
+	return fmt.Sprintf("\n  %s\n\n%s\n  %s\n\n  %s\n", title, list, rotation, help)
 }
 
 // BackToMenuMsg signals to return to the main menu
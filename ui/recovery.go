@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+// ActiveSessionFoundMsg carries an unfinished session left by a previous run
+type ActiveSessionFoundMsg struct {
+	Session *db.ActiveSession
+	Err     error
+}
+
+// loadActiveSessionCmd checks for a session that was running when the app exited
+func loadActiveSessionCmd() tea.Cmd {
+	return func() tea.Msg {
+		session, err := db.LoadActiveSession()
+		return ActiveSessionFoundMsg{Session: session, Err: err}
+	}
+}
+
+// clearActiveSessionCmd forgets the in-progress session record
+func clearActiveSessionCmd() tea.Cmd {
+	return func() tea.Msg {
+		db.ClearActiveSession()
+		return nil
+	}
+}
+
+// renderRecovery asks whether to resume or discard an unfinished session
+func renderRecovery(a db.ActiveSession) string {
+	title := WarningStyle.Render("An unfinished vow remains.")
+
+	elapsed := a.ElapsedSeconds / 60
+	message := NormalStyle.Render(fmt.Sprintf(
+		"%s: %d of %d minutes kept before Beot closed\n(started %s).",
+		a.SubjectName, elapsed, a.Duration, RelativeTime(a.StartedAt),
+	))
+	if time.Since(a.SavedAt) > 12*time.Hour {
+		message += "\n" + HelpStyle.Render("It has been a while — you may prefer to begin anew.")
+	}
+
+	content := fmt.Sprintf(
+		"%s\n\n%s\n\n%s",
+		title,
+		message,
+		HelpStyle.Render("[r] resume • [d] discard"),
+	)
+
+	return "\n" + BoxStyle.Render(content) + "\n"
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"Beot/db"
+)
+
+// ReflectionModel asks how a just-completed session went: a 1-5 rating,
+// then an optional note
+type ReflectionModel struct {
+	sessionID   primitive.ObjectID
+	subjectName string
+	rating      int // 0 until chosen; the note step follows
+	note        textinput.Model
+	err         error
+}
+
+// ReflectionDoneMsg is sent when the reflection is saved or skipped
+type ReflectionDoneMsg struct {
+	Err error
+}
+
+func NewReflectionModel(sessionID primitive.ObjectID, subjectName string) ReflectionModel {
+	ti := textinput.New()
+	ti.Placeholder = "A line on how it went (optional)"
+	ti.CharLimit = 200
+	ti.Width = 60
+
+	return ReflectionModel{sessionID: sessionID, subjectName: subjectName, note: ti}
+}
+
+// typing reports whether the note field is taking input
+func (m ReflectionModel) typing() bool {
+	return m.rating > 0
+}
+
+func (m ReflectionModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ReflectionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if done, ok := msg.(ReflectionDoneMsg); ok {
+		m.err = done.Err
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.note, cmd = m.note.Update(msg)
+		return m, cmd
+	}
+
+	// Rating step
+	if m.rating == 0 {
+		switch key := keyMsg.String(); key {
+		case "esc":
+			return m, func() tea.Msg { return ReflectionDoneMsg{} }
+		case "1", "2", "3", "4", "5":
+			m.rating = int(key[0] - '0')
+			return m, m.note.Focus()
+		}
+		return m, nil
+	}
+
+	// Note step; esc keeps the rating without a note
+	switch keyMsg.String() {
+	case "esc":
+		return m, m.saveCmd("")
+	case "enter":
+		return m, m.saveCmd(strings.TrimSpace(m.note.Value()))
+	}
+	var cmd tea.Cmd
+	m.note, cmd = m.note.Update(msg)
+	return m, cmd
+}
+
+// saveCmd stores the rating and note on the session
+func (m ReflectionModel) saveCmd(note string) tea.Cmd {
+	id, rating := m.sessionID, m.rating
+	return func() tea.Msg {
+		return ReflectionDoneMsg{Err: db.SetSessionReflection(id, rating, note)}
+	}
+}
+
+// renderRating draws the 1-5 scale, filling stars up to rating
+func renderRating(rating int) string {
+	var stars []string
+	for i := 1; i <= db.MaxRating; i++ {
+		if i <= rating {
+			stars = append(stars, StreakStyle.Render("★"))
+		} else {
+			stars = append(stars, HelpStyle.Render("☆"))
+		}
+	}
+	return strings.Join(stars, " ")
+}
+
+func (m ReflectionModel) View() string {
+	title := TitleStyle.Render("Reflect on Your Vow")
+	subject := StatusStyle.Render(fmt.Sprintf("Subject: %s", m.subjectName))
+
+	if m.rating == 0 {
+		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n\n  %s\n\n  %s\n",
+			title,
+			subject,
+			NormalStyle.Render("How did it go?"),
+			renderRating(0)+HelpStyle.Render("   1 poor … 5 excellent"),
+			HelpStyle.Render("1-5 rate • esc skip"),
+		)
+	}
+
+	status := ""
+	if m.err != nil {
+		status = "\n  " + ErrorStyle.Render("Could not save: "+m.err.Error()) + "\n"
+	}
+
+	return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n\n  %s\n%s\n  %s\n",
+		title,
+		subject,
+		renderRating(m.rating),
+		m.note.View(),
+		status,
+		HelpStyle.Render("enter save • esc save without a note"),
+	)
+}
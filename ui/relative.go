@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelativeTime formats t as a short human-friendly age, e.g. "3h ago" or
+// "yesterday". Anything older than a fortnight shows its date.
+func RelativeTime(t time.Time) string {
+	return relativeTimeAt(t, time.Now())
+}
+
+// relativeTimeAt is RelativeTime measured from now. Within the hour it
+// counts minutes; after that it goes by calendar dates in now's time zone,
+// so 23:00 seen at 01:00 is "yesterday", not "2h ago".
+func relativeTimeAt(t, now time.Time) string {
+	t = t.In(now.Location())
+	d := now.Sub(t)
+	if d < time.Minute {
+		return "just now" // Including clock skew that puts t slightly ahead
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	}
+
+	switch days := calendarDaysBetween(t, now); {
+	case days == 0:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case days == 1:
+		return "yesterday"
+	case days < 7:
+		return fmt.Sprintf("%dd ago", days)
+	case days < 14:
+		return "last week"
+	default:
+		return t.Format("2 Jan 2006")
+	}
+}
+
+// calendarDaysBetween counts the midnights from t's date to now's. Dates
+// are compared in UTC so a DST change can't make a day 23 or 25 hours long.
+func calendarDaysBetween(t, now time.Time) int {
+	from := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// ExactTime formats t in full local time, for when "3h ago" is not enough
+func ExactTime(t time.Time) string {
+	return t.Local().Format("Mon 2 Jan 2006 15:04")
+}
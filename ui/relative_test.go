@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{-5 * time.Second, "just now"},
+		{0, "just now"},
+		{59 * time.Second, "just now"},
+		{60 * time.Second, "1m ago"},
+		{59 * time.Minute, "59m ago"},
+		{time.Hour, "1h ago"},
+		{12 * time.Hour, "12h ago"},               // Midnight today
+		{12*time.Hour + time.Minute, "yesterday"}, // 23:59 yesterday
+		{36 * time.Hour, "yesterday"},             // Midnight yesterday
+		{36*time.Hour + time.Minute, "2d ago"},
+		{7*24*time.Hour - time.Minute, "last week"}, // Seven dates back
+		{6 * 24 * time.Hour, "6d ago"},
+		{13*24*time.Hour + 12*time.Hour, "last week"},
+		{14 * 24 * time.Hour, "24 Feb 2026"},
+	}
+	for _, tt := range tests {
+		if got := relativeTimeAt(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("%v ago = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestRelativeTimeAcrossMidnight(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2026, 3, 10, 1, 0, 0, 0, loc)
+
+	// Two hours ago, but on yesterday's date
+	if got := relativeTimeAt(now.Add(-2*time.Hour), now); got != "yesterday" {
+		t.Errorf("23:00 seen at 01:00 = %q, want yesterday", got)
+	}
+	// 30 hours ago is two dates back
+	if got := relativeTimeAt(now.Add(-30*time.Hour), now); got != "2d ago" {
+		t.Errorf("19:00 two days ago = %q, want 2d ago", got)
+	}
+	// Dates are taken in now's zone, not t's
+	if got := relativeTimeAt(time.Date(2026, 3, 9, 21, 30, 0, 0, time.UTC), now); got != "yesterday" {
+		t.Errorf("23:30 yesterday stored in UTC = %q, want yesterday", got)
+	}
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+// statsReport holds what the markdown export shows
+type statsReport struct {
+	Generated  time.Time
+	Stats      *db.SessionStats
+	BySubject  map[string]int // Minutes
+	WeekStart  time.Time      // Local midnight on the first day of this week
+	WeekDays   [7]int         // Minutes on each day from WeekStart
+	WeeklyGoal int            // Minutes, 0 = none
+}
+
+// ExportStatsMarkdown writes a weekly review of the stats as markdown: totals,
+// streaks, minutes per subject and this week day by day
+func ExportStatsMarkdown(w io.Writer) error {
+	r := statsReport{Generated: time.Now()}
+
+	var err error
+	if r.Stats, err = db.GetSessionStats(); err != nil {
+		return err
+	}
+	if r.BySubject, err = db.GetMinutesBySubject(); err != nil {
+		return err
+	}
+	if r.WeeklyGoal, err = db.GetWeeklyGoal(); err != nil {
+		return err
+	}
+	start, err := db.GetWeekStart()
+	if err != nil {
+		return err
+	}
+	r.WeekStart = db.StartOfWeek(r.Generated, start)
+	days, err := db.GetMinutesByDay(r.WeekStart, len(r.WeekDays))
+	if err != nil {
+		return err
+	}
+	copy(r.WeekDays[:], days)
+
+	_, err = io.WriteString(w, renderStatsMarkdown(r))
+	return err
+}
+
+// renderStatsMarkdown formats the report; days after Generated are left blank
+func renderStatsMarkdown(r statsReport) string {
+	var b strings.Builder
+
+	b.WriteString("# Bēot Weekly Review\n\n")
+	b.WriteString(fmt.Sprintf("_Generated %s_\n\n", r.Generated.Format("Monday 2 January 2006, 15:04")))
+
+	b.WriteString("## Totals\n\n")
+	b.WriteString(fmt.Sprintf("- **Focus time:** %s\n", formatMinutes(r.Stats.TotalMinutes)))
+	b.WriteString(fmt.Sprintf("- **Sessions:** %d completed, %d abandoned\n", r.Stats.CompletedSessions, r.Stats.AbandonedSessions))
+	b.WriteString(fmt.Sprintf("- **Current streak:** %s\n", pluralDays(r.Stats.CurrentStreak)))
+	b.WriteString(fmt.Sprintf("- **Longest streak:** %s\n", pluralDays(r.Stats.LongestStreak)))
+
+	b.WriteString("\n## Subjects\n\n")
+	if subjects := sortSubjectTotals(r.BySubject); len(subjects) == 0 {
+		b.WriteString("No completed sessions yet.\n")
+	} else {
+		b.WriteString("| Subject | Minutes | Time |\n|---|--:|--:|\n")
+		for _, s := range subjects {
+			b.WriteString(fmt.Sprintf("| %s | %d | %s |\n", markdownCell(s.name), s.total, formatMinutes(s.total)))
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\n## Week of %s\n\n", r.WeekStart.Format("2 January 2006")))
+	b.WriteString("| Day | Focus |\n|---|--:|\n")
+	total := 0
+	for i, minutes := range r.WeekDays {
+		day := r.WeekStart.AddDate(0, 0, i)
+		focus := formatMinutes(minutes)
+		if day.After(r.Generated) {
+			focus = ""
+		}
+		total += minutes
+		b.WriteString(fmt.Sprintf("| %s | %s |\n", day.Format("Mon 2 Jan"), focus))
+	}
+	b.WriteString(fmt.Sprintf("| **Total** | **%s** |\n", formatMinutes(total)))
+	if r.WeeklyGoal > 0 {
+		b.WriteString(fmt.Sprintf("\nWeekly goal: %s of %s (%d%%)\n", formatMinutes(total), formatMinutes(r.WeeklyGoal), total*100/r.WeeklyGoal))
+	}
+	return b.String()
+}
+
+// pluralDays formats a streak length as "1 day" or "3 days"
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+// markdownCell escapes the characters that would break a table row
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// StatsExportedMsg reports where the markdown review was written
+type StatsExportedMsg struct {
+	Path string
+	Err  error
+}
+
+// reviewDir returns BEOT_REVIEW_DIR, or reviews/ beside the config file
+func reviewDir() (string, error) {
+	if dir := os.Getenv("BEOT_REVIEW_DIR"); dir != "" {
+		return dir, nil
+	}
+	config, err := db.ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(config), "reviews"), nil
+}
+
+// createReviewFile makes a new dated file in dir. A second review on the
+// same day gets a numbered name rather than replacing the first.
+func createReviewFile(dir string, day time.Time) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	base := "beot-review-" + day.Format("2006-01-02")
+	for n := 1; ; n++ {
+		name := base + ".md"
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d.md", base, n)
+		}
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if !errors.Is(err, fs.ErrExist) {
+			return f, err
+		}
+	}
+}
+
+// exportStatsCmd writes the markdown review to a new dated file in reviewDir
+func exportStatsCmd() tea.Cmd {
+	return func() tea.Msg {
+		dir, err := reviewDir()
+		if err != nil {
+			return StatsExportedMsg{Err: err}
+		}
+		f, err := createReviewFile(dir, time.Now())
+		if err != nil {
+			return StatsExportedMsg{Err: err}
+		}
+		if err := ExportStatsMarkdown(f); err != nil {
+			f.Close()
+			return StatsExportedMsg{Err: err}
+		}
+		return StatsExportedMsg{Path: f.Name(), Err: f.Close()}
+	}
+}
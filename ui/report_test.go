@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"Beot/db"
+)
+
+func TestRenderStatsMarkdown(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	r := statsReport{
+		Generated:  monday.AddDate(0, 0, 2).Add(18 * time.Hour),
+		Stats:      &db.SessionStats{TotalMinutes: 200, CompletedSessions: 8, AbandonedSessions: 1, CurrentStreak: 1, LongestStreak: 4},
+		BySubject:  map[string]int{"Go": 150, "Read|Write": 50},
+		WeekStart:  monday,
+		WeekDays:   [7]int{25, 0, 50},
+		WeeklyGoal: 300,
+	}
+	got := renderStatsMarkdown(r)
+
+	for _, want := range []string{
+		"# Bēot Weekly Review",
+		"_Generated Wednesday 4 March 2026, 18:00_",
+		"- **Current streak:** 1 day",
+		"- **Longest streak:** 4 days",
+		"| Go | 150 | 2h 30m |\n| Read\\|Write | 50 | 50m |",
+		"## Week of 2 March 2026",
+		"| Mon 2 Mar | 25m |\n| Tue 3 Mar | 0m |\n| Wed 4 Mar | 50m |\n| Thu 5 Mar |  |",
+		"| **Total** | **1h 15m** |",
+		"Weekly goal: 1h 15m of 5h 0m (25%)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestCreateReviewFileKeepsEarlierReviews(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reviews")
+	day := time.Date(2026, 3, 4, 18, 0, 0, 0, time.Local)
+
+	var names []string
+	for range 3 {
+		f, err := createReviewFile(dir, day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		names = append(names, filepath.Base(f.Name()))
+	}
+	want := []string{"beot-review-2026-03-04.md", "beot-review-2026-03-04-2.md", "beot-review-2026-03-04-3.md"}
+	if strings.Join(names, " ") != strings.Join(want, " ") {
+		t.Errorf("files = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,626 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/alert"
+	"Beot/db"
+	"Beot/notify"
+)
+
+// settingsField describes one numeric input on the settings screen
+type settingsField struct {
+	label string
+	min   int
+	max   int
+}
+
+// settingsFields are the numeric inputs: the four durations, the weekly
+// goal, then the quote length limit
+var settingsFields = []settingsField{
+	{label: "Focus block (minutes)", min: 1, max: 240},
+	{label: "Short break (min, 0 off)", min: 0, max: 60},
+	{label: "Long break (min, 0 off)", min: 0, max: 120},
+	{label: "Blocks before long break", min: 1, max: 12},
+	{label: "Weekly goal (hours, 0 off)", min: 0, max: 168},
+	{label: "Quote limit (chars, 0 off)", min: 0, max: 999},
+}
+
+// Indexes of the non-duration inputs in settingsFields
+const (
+	weeklyGoalInput = 4
+	quoteMaxInput   = 5
+)
+
+// nightModeOptions are the start times offered for night mode; "" is off
+var nightModeOptions = []string{"", "19:00", "20:00", "21:00", "22:00", "23:00", "00:00"}
+
+// settingsOption is one row below the numeric inputs. Space and →/← call
+// change with a step of 1 or -1; each change is saved straight away.
+type settingsOption struct {
+	label  string
+	value  func(m SettingsModel) string // Current value as shown
+	hint   func(m SettingsModel) string // Note after the value, optional
+	change func(m SettingsModel, step int) (SettingsModel, tea.Cmd)
+	lock   func() string // Environment variable fixing the value, "" if none; optional
+}
+
+// settingsOptions are the option rows in screen order
+var settingsOptions = []settingsOption{
+	{
+		label:  "Completion alert",
+		value:  func(m SettingsModel) string { return m.alert.Label() },
+		change: func(m SettingsModel, _ int) (SettingsModel, tea.Cmd) { return m.cycleAlert() },
+		lock:   envLock("BEOT_ALERT", func() bool { _, forced := alert.Override(); return forced }),
+	},
+	{
+		label:  "Intro vow at launch",
+		value:  func(m SettingsModel) string { return onOff(m.splash) },
+		change: func(m SettingsModel, _ int) (SettingsModel, tea.Cmd) { return m.toggleSplash() },
+	},
+	{
+		label: "Night mode after",
+		value: func(m SettingsModel) string {
+			if m.nightAfter == "" {
+				return "Off"
+			}
+			return m.nightAfter
+		},
+		change: SettingsModel.cycleNightMode,
+	},
+	{
+		label:  "Week starts on",
+		value:  func(m SettingsModel) string { return m.weekStart.String() },
+		change: func(m SettingsModel, _ int) (SettingsModel, tea.Cmd) { return m.toggleWeekStart() },
+		lock:   envLock("BEOT_WEEK_START", func() bool { _, forced := db.WeekStartOverride(); return forced }),
+	},
+	{
+		label: "Streak freezes per week",
+		value: func(m SettingsModel) string {
+			if m.freezes == 0 {
+				return "Off"
+			}
+			return strconv.Itoa(m.freezes)
+		},
+		hint: func(m SettingsModel) string {
+			if m.freezes > 0 {
+				return "a single missed day keeps the streak"
+			}
+			return ""
+		},
+		change: SettingsModel.cycleStreakFreezes,
+	},
+	{
+		label: "Abandoned keep streaks",
+		value: func(m SettingsModel) string { return onOff(m.abandoned) },
+		hint: func(m SettingsModel) string {
+			if m.abandoned {
+				return "showing up counts, even if you give up"
+			}
+			return ""
+		},
+		change: func(m SettingsModel, _ int) (SettingsModel, tea.Cmd) { return m.toggleStreakAbandoned() },
+	},
+	{
+		label: "Auto-start breaks",
+		value: func(m SettingsModel) string { return onOff(!m.holdBreaks) },
+		change: func(m SettingsModel, _ int) (SettingsModel, tea.Cmd) {
+			m.holdBreaks = !m.holdBreaks
+			return m.saveAutoStart()
+		},
+	},
+	{
+		label: "Auto-start focus blocks",
+		value: func(m SettingsModel) string { return onOff(m.autoWork) },
+		change: func(m SettingsModel, _ int) (SettingsModel, tea.Cmd) {
+			m.autoWork = !m.autoWork
+			return m.saveAutoStart()
+		},
+	},
+	{
+		label:  "Desktop notifications",
+		value:  func(m SettingsModel) string { return onOff(m.notifications) },
+		change: func(m SettingsModel, _ int) (SettingsModel, tea.Cmd) { return m.toggleNotifications() },
+		lock:   envLock("BEOT_NOTIFICATIONS", notify.Forced),
+	},
+	{
+		label:  "Reduced motion",
+		value:  func(m SettingsModel) string { return onOff(m.stillTimer) },
+		change: func(m SettingsModel, _ int) (SettingsModel, tea.Cmd) { return m.toggleReducedMotion() },
+		lock:   envLock("BEOT_REDUCED_MOTION", func() bool { return reducedMotionForced }),
+	},
+}
+
+// envLock names the variable while forced reports it is set
+func envLock(name string, forced func() bool) func() string {
+	return func() string {
+		if forced() {
+			return name
+		}
+		return ""
+	}
+}
+
+// lockedBy returns the variable fixing the option's value, or ""
+func (o settingsOption) lockedBy() string {
+	if o.lock == nil {
+		return ""
+	}
+	return o.lock()
+}
+
+// onOff labels a toggle
+func onOff(on bool) string {
+	if on {
+		return "On"
+	}
+	return "Off"
+}
+
+type SettingsModel struct {
+	inputs        []textinput.Model
+	inputFocus    int        // From len(inputs), the rows of settingsOptions
+	alert         alert.Mode // Completion alert, saved as soon as it changes
+	splash        bool       // Show the intro vow at launch, saved as soon as it changes
+	nightAfter    string     // Night mode start, saved as soon as it changes
+	weekStart     time.Weekday
+	freezes       int  // Missed days a week a streak survives, saved as soon as it changes
+	abandoned     bool // Abandoned sessions count towards streaks, saved as soon as it changes
+	holdBreaks    bool // Wait for a key before breaks, saved as soon as it changes
+	autoWork      bool // Start focus blocks when a break ends, saved as soon as it changes
+	stillTimer    bool // Reduced motion: no pulse on the countdown, saved as soon as it changes
+	notifications bool // Desktop notifications, saved as soon as it changes
+	saved         bool
+	err           error
+}
+
+func NewSettingsModel() SettingsModel {
+	inputs := make([]textinput.Model, len(settingsFields))
+	for i := range inputs {
+		ti := textinput.New()
+		ti.CharLimit = 3
+		ti.Width = 6
+		inputs[i] = ti
+	}
+	inputs[0].Focus()
+
+	return SettingsModel{inputs: inputs}
+}
+
+type DurationsLoadedMsg struct {
+	Durations  db.Durations
+	WeeklyGoal int // Minutes
+	QuoteMax   int // Characters, 0 = no limit
+	WeekStart  time.Weekday
+	Freezes    int  // Streak freezes per week, 0 = off
+	Abandoned  bool // Abandoned sessions count towards streaks
+	Err        error
+}
+
+type DurationsSavedMsg struct {
+	Durations  db.Durations
+	WeeklyGoal int // Minutes
+	QuoteMax   int // Characters, 0 = no limit
+	Err        error
+}
+
+// AlertChangedMsg is sent when the completion alert setting is saved
+type AlertChangedMsg struct {
+	Mode alert.Mode
+	Err  error
+}
+
+// NightModeChangedMsg is sent when the night mode start time is saved
+type NightModeChangedMsg struct {
+	After string
+	Err   error
+}
+
+// WeekStartChangedMsg is sent when the first day of the week is saved
+type WeekStartChangedMsg struct {
+	Day time.Weekday
+	Err error
+}
+
+// StreakFreezesChangedMsg is sent when the weekly streak freeze allowance is saved
+type StreakFreezesChangedMsg struct {
+	PerWeek int
+	Err     error
+}
+
+// StreakAbandonedChangedMsg is sent when the abandoned-sessions streak setting is saved
+type StreakAbandonedChangedMsg struct {
+	CountAbandoned bool
+	Err            error
+}
+
+// AutoStartChangedMsg is sent when the auto-start settings are saved
+type AutoStartChangedMsg struct {
+	HoldBreaks    bool
+	AutoStartWork bool
+	Err           error
+}
+
+// ReducedMotionChangedMsg is sent when the reduced motion setting is saved
+type ReducedMotionChangedMsg struct {
+	On  bool
+	Err error
+}
+
+// NotificationsChangedMsg is sent when the desktop notifications setting is saved
+type NotificationsChangedMsg struct {
+	On  bool
+	Err error
+}
+
+// SplashChangedMsg is sent when the intro vow setting is saved
+type SplashChangedMsg struct {
+	Show bool
+	Err  error
+}
+
+func (m *SettingsModel) LoadSettings() tea.Cmd {
+	return func() tea.Msg {
+		d, err := db.GetDurations()
+		if err != nil {
+			return DurationsLoadedMsg{Durations: d, Err: err}
+		}
+		goal, err := db.GetWeeklyGoal()
+		if err != nil {
+			return DurationsLoadedMsg{Durations: d, Err: err}
+		}
+		quoteMax, err := db.GetQuoteMaxChars()
+		if err != nil {
+			return DurationsLoadedMsg{Durations: d, Err: err}
+		}
+		weekStart, err := db.GetWeekStart()
+		if err != nil {
+			return DurationsLoadedMsg{Durations: d, Err: err}
+		}
+		freezes, err := db.GetStreakFreezesPerWeek()
+		if err != nil {
+			return DurationsLoadedMsg{Durations: d, Err: err}
+		}
+		abandoned, err := db.GetStreaksCountAbandoned()
+		return DurationsLoadedMsg{Durations: d, WeeklyGoal: goal, QuoteMax: quoteMax, WeekStart: weekStart, Freezes: freezes, Abandoned: abandoned, Err: err}
+	}
+}
+
+func (m SettingsModel) Init() tea.Cmd {
+	return tea.Batch(m.LoadSettings(), textinput.Blink)
+}
+
+func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case DurationsLoadedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		}
+		d := msg.Durations
+		for i, v := range []int{d.Work, d.ShortBreak, d.LongBreak, d.Cycle, msg.WeeklyGoal / 60, msg.QuoteMax} {
+			m.inputs[i].SetValue(strconv.Itoa(v))
+		}
+		m.weekStart = msg.WeekStart
+		m.freezes = msg.Freezes
+		m.abandoned = msg.Abandoned
+		m.holdBreaks, m.autoWork = d.HoldBreaks, d.AutoStartWork
+		return m, nil
+
+	case DurationsSavedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case NightModeChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case WeekStartChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case StreakFreezesChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case StreakAbandonedChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case AutoStartChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case ReducedMotionChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case NotificationsChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case SplashChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case AlertChangedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+		} else {
+			m.saved = true
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		m.saved = false
+		rows := len(m.inputs) + len(settingsOptions)
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return BackToMenuMsg{} }
+		case "tab", "down":
+			m.focusInput((m.inputFocus + 1) % rows)
+			return m, nil
+		case "shift+tab", "up":
+			m.focusInput((m.inputFocus + rows - 1) % rows)
+			return m, nil
+		case "enter":
+			return m.save()
+		}
+
+		if i := m.inputFocus - len(m.inputs); i >= 0 {
+			option := settingsOptions[i]
+			step := 0
+			switch msg.String() {
+			case " ", "right":
+				step = 1
+			case "left":
+				step = -1
+			}
+			if step == 0 || option.lockedBy() != "" {
+				return m, nil
+			}
+			return option.change(m, step)
+		}
+
+		// Only digits and editing keys reach the inputs
+		if msg.Type == tea.KeyRunes {
+			for _, r := range msg.Runes {
+				if r < '0' || r > '9' {
+					return m, nil
+				}
+			}
+		}
+		var cmd tea.Cmd
+		m.inputs[m.inputFocus], cmd = m.inputs[m.inputFocus].Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// focusInput moves focus to the given field; the option rows have no text input
+func (m *SettingsModel) focusInput(i int) {
+	if m.inputFocus < len(m.inputs) {
+		m.inputs[m.inputFocus].Blur()
+	}
+	m.inputFocus = i
+	if i < len(m.inputs) {
+		m.inputs[i].Focus()
+	}
+}
+
+// cycleAlert switches to the next alert mode, plays it as a preview and saves it
+func (m SettingsModel) cycleAlert() (SettingsModel, tea.Cmd) {
+	m.alert = m.alert.Next()
+	mode := m.alert
+	alert.Play(mode)
+	return m, func() tea.Msg {
+		err := db.SetSetting(db.SettingAlert, mode.String())
+		return AlertChangedMsg{Mode: mode, Err: err}
+	}
+}
+
+// toggleReducedMotion turns the countdown pulse off or on and saves it
+func (m SettingsModel) toggleReducedMotion() (SettingsModel, tea.Cmd) {
+	m.stillTimer = !m.stillTimer
+	on := m.stillTimer
+	return m, func() tea.Msg {
+		err := db.SetBoolSetting(db.SettingReducedMotion, on)
+		return ReducedMotionChangedMsg{On: on, Err: err}
+	}
+}
+
+// toggleNotifications turns desktop notifications off or on and saves it
+func (m SettingsModel) toggleNotifications() (SettingsModel, tea.Cmd) {
+	m.notifications = !m.notifications
+	on := m.notifications
+	return m, func() tea.Msg {
+		err := db.SetBoolSetting(db.SettingNotifyOff, !on)
+		return NotificationsChangedMsg{On: on, Err: err}
+	}
+}
+
+// toggleSplash turns the intro vow on or off and saves it
+func (m SettingsModel) toggleSplash() (SettingsModel, tea.Cmd) {
+	m.splash = !m.splash
+	show := m.splash
+	return m, func() tea.Msg {
+		err := db.SetBoolSetting(db.SettingSkipSplash, !show)
+		return SplashChangedMsg{Show: show, Err: err}
+	}
+}
+
+// toggleWeekStart switches the week between Monday and Sunday starts and saves it
+func (m SettingsModel) toggleWeekStart() (SettingsModel, tea.Cmd) {
+	if m.weekStart == time.Sunday {
+		m.weekStart = time.Monday
+	} else {
+		m.weekStart = time.Sunday
+	}
+	day := m.weekStart
+	return m, func() tea.Msg {
+		err := db.SetWeekStart(day)
+		return WeekStartChangedMsg{Day: day, Err: err}
+	}
+}
+
+// toggleStreakAbandoned chooses whether abandoned sessions keep a streak going
+func (m SettingsModel) toggleStreakAbandoned() (SettingsModel, tea.Cmd) {
+	m.abandoned = !m.abandoned
+	on := m.abandoned
+	return m, func() tea.Msg {
+		err := db.SetStreaksCountAbandoned(on)
+		return StreakAbandonedChangedMsg{CountAbandoned: on, Err: err}
+	}
+}
+
+// saveAutoStart stores the auto-start toggles
+func (m SettingsModel) saveAutoStart() (SettingsModel, tea.Cmd) {
+	hold, work := m.holdBreaks, m.autoWork
+	return m, func() tea.Msg {
+		err := db.SetAutoStart(!hold, work)
+		return AutoStartChangedMsg{HoldBreaks: hold, AutoStartWork: work, Err: err}
+	}
+}
+
+// cycleStreakFreezes steps the weekly freeze allowance between off and
+// db.MaxStreakFreezes and saves it
+func (m SettingsModel) cycleStreakFreezes(step int) (SettingsModel, tea.Cmd) {
+	m.freezes = (m.freezes + step + db.MaxStreakFreezes + 1) % (db.MaxStreakFreezes + 1)
+	perWeek := m.freezes
+	return m, func() tea.Msg {
+		err := db.SetStreakFreezesPerWeek(perWeek)
+		return StreakFreezesChangedMsg{PerWeek: perWeek, Err: err}
+	}
+}
+
+// cycleNightMode steps through nightModeOptions and saves the choice. A
+// time not on the list (e.g. set by hand) steps from off.
+func (m SettingsModel) cycleNightMode(step int) (SettingsModel, tea.Cmd) {
+	i := 0
+	for j, option := range nightModeOptions {
+		if option == m.nightAfter {
+			i = j
+		}
+	}
+	n := len(nightModeOptions)
+	m.nightAfter = nightModeOptions[(i+step+n)%n]
+	after := m.nightAfter
+	return m, func() tea.Msg {
+		err := db.SetSetting(db.SettingNightMode, after)
+		return NightModeChangedMsg{After: after, Err: err}
+	}
+}
+
+// save validates every field and persists the durations, weekly goal and quote limit
+func (m SettingsModel) save() (tea.Model, tea.Cmd) {
+	values := make([]int, len(m.inputs))
+	for i, input := range m.inputs {
+		n, err := strconv.Atoi(input.Value())
+		field := settingsFields[i]
+		if err != nil || n < field.min || n > field.max {
+			m.err = fmt.Errorf("%s must be between %d and %d", field.label, field.min, field.max)
+			m.focusInput(i)
+			return m, nil
+		}
+		values[i] = n
+	}
+	m.err = nil
+
+	d := db.Durations{Work: values[0], ShortBreak: values[1], LongBreak: values[2], Cycle: values[3], HoldBreaks: m.holdBreaks, AutoStartWork: m.autoWork}
+	goal := values[weeklyGoalInput] * 60
+	quoteMax := values[quoteMaxInput]
+	return m, func() tea.Msg {
+		saved := DurationsSavedMsg{Durations: d, WeeklyGoal: goal, QuoteMax: quoteMax}
+		if saved.Err = db.SetDurations(d.Work, d.ShortBreak, d.LongBreak, d.Cycle); saved.Err != nil {
+			return saved
+		}
+		if saved.Err = db.SetWeeklyGoal(goal); saved.Err != nil {
+			return saved
+		}
+		saved.Err = db.SetQuoteMaxChars(quoteMax)
+		return saved
+	}
+}
+
+func (m SettingsModel) View() string {
+	title := TitleStyle.Render("⚙ Settings")
+
+	var form string
+	for i, field := range settingsFields {
+		label := NormalStyle.Render(fmt.Sprintf("%-26s", field.label))
+		if i == m.inputFocus {
+			label = SelectedStyle.Render(fmt.Sprintf("%-26s", field.label))
+		}
+		form += fmt.Sprintf("  %s %s\n", label, m.inputs[i].View())
+	}
+
+	form += "\n"
+	for i, option := range settingsOptions {
+		label := NormalStyle.Render(fmt.Sprintf("%-26s", option.label))
+		if m.inputFocus == len(m.inputs)+i {
+			label = SelectedStyle.Render(fmt.Sprintf("%-26s", option.label))
+		}
+		value := "◂ " + option.value(m) + " ▸"
+		if env := option.lockedBy(); env != "" {
+			value = option.value(m) + HelpStyle.Render(" (set by "+env+")")
+		}
+		if option.hint != nil {
+			if hint := option.hint(m); hint != "" {
+				value += HelpStyle.Render(" (" + hint + ")")
+			}
+		}
+		form += fmt.Sprintf("  %s %s\n", label, value)
+	}
+
+	status := ""
+	if m.err != nil {
+		status = "\n  " + ErrorStyle.Render(m.err.Error()) + "\n"
+	} else if m.saved {
+		status = "\n  " + SuccessStyle.Render("Settings saved.") + "\n"
+	}
+
+	help := HelpStyle.Render("tab/↑/↓ switch field • space change option • enter save • esc back")
+
+	return fmt.Sprintf("\n  %s\n\n%s%s\n  %s\n", title, form, status, help)
+}
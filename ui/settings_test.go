@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+	"Beot/notify"
+)
+
+func TestSettingsOptionRows(t *testing.T) {
+	t.Setenv("BEOT_WEEK_START", "sunday")
+	m := NewSettingsModel()
+
+	// Every option row is reachable and shown
+	for i, option := range settingsOptions {
+		m.focusInput(len(m.inputs) + i)
+		if !strings.Contains(m.View(), option.label) {
+			t.Errorf("%q is missing from the view", option.label)
+		}
+	}
+
+	week := -1
+	for i, option := range settingsOptions {
+		if option.label == "Week starts on" {
+			week = i
+		}
+	}
+	m.focusInput(len(m.inputs) + week)
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if cmd != nil || next.(SettingsModel).weekStart != m.weekStart {
+		t.Error("a row locked by the environment should not change")
+	}
+	if !strings.Contains(m.View(), "(set by BEOT_WEEK_START)") {
+		t.Error("the locked row should name its variable")
+	}
+}
+
+func TestSettingsNotificationsToggle(t *testing.T) {
+	if notify.Forced() {
+		t.Skip("BEOT_NOTIFICATIONS is set")
+	}
+	useTempStore(t)
+	m := NewSettingsModel()
+	m.notifications = true
+	for i, option := range settingsOptions {
+		if option.label == "Desktop notifications" {
+			m.focusInput(len(m.inputs) + i)
+		}
+	}
+
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	if next.(SettingsModel).notifications || cmd == nil {
+		t.Fatal("space should turn notifications off and save")
+	}
+	if msg := cmd().(NotificationsChangedMsg); msg.Err != nil || msg.On {
+		t.Fatalf("saved %+v, want off", msg)
+	}
+	if off, _ := db.GetBoolSetting(db.SettingNotifyOff); !off {
+		t.Error("the setting should be stored as off")
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splashVow is the intro spoken before the menu, from the README
+var splashVow = []string{
+	"When you start a session, you are making a vow:",
+	"for the time you set, you will hold to this work.",
+	"",
+	"Completing a session is keeping your word.",
+	"Abandoning it is breaking your vow.",
+}
+
+// splashBeowulf is Beowulf's own bēot before facing Grendel, as in the README
+var splashBeowulf = []string{
+	"Ic þæt þonne forhicge,",
+	"swā mē Higelāc sīe mīn mundbora,",
+	"wiþ þā grimman gryre-gæst Grendel",
+	"gefeohtan fēa sīðe,",
+	"hand-geswingum, swā hē hēa rīce",
+	"for ealra þāra eorla rīcsode.",
+}
+
+// renderSplash shows the banner and the full vow once at launch
+func renderSplash() string {
+	vow := NormalStyle.Render(strings.Join(splashVow, "\n"))
+	poem := OldEnglishStyle.Render(strings.Join(splashBeowulf, "\n"))
+
+	return fmt.Sprintf("\n%s\n\n  %s\n\n%s\n    %s\n\n  %s\n",
+		RenderBanner(),
+		strings.ReplaceAll(vow, "\n", "\n  "),
+		poem,
+		HelpStyle.Render("— Beowulf, approx. lines 433–441"),
+		HelpStyle.Render("Press any key to begin"),
+	)
+}
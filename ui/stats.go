@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"Beot/db"
+)
+
+// statsRange is the period the stats screen's session counts cover
+type statsRange int
+
+const (
+	rangeAllTime statsRange = iota
+	rangeLast7Days
+	rangeLast30Days
+)
+
+// Next returns the range after r, wrapping back to all time
+func (r statsRange) Next() statsRange {
+	return (r + 1) % 3
+}
+
+// Label names the range for the stats screen
+func (r statsRange) Label() string {
+	switch r {
+	case rangeLast7Days:
+		return "Last 7 Days"
+	case rangeLast30Days:
+		return "Last 30 Days"
+	default:
+		return "All Time"
+	}
+}
+
+// Since returns the start of the range: midnight local time on the first
+// day, so "last 7 days" is today and the six before it. All time is zero.
+func (r statsRange) Since(now time.Time) time.Time {
+	days := 0
+	switch r {
+	case rangeLast7Days:
+		days = 7
+	case rangeLast30Days:
+		days = 30
+	default:
+		return time.Time{}
+	}
+	y, mo, d := now.Date()
+	return time.Date(y, mo, d-days+1, 0, 0, 0, 0, now.Location())
+}
+
+// RangeStatsLoadedMsg carries session counts for a stats range
+type RangeStatsLoadedMsg struct {
+	Range statsRange
+	Stats *db.SessionStats
+	Err   error
+}
+
+// loadRangeStatsCmd fetches session counts limited to a range
+func loadRangeStatsCmd(r statsRange) tea.Cmd {
+	return func() tea.Msg {
+		stats, err := db.GetSessionStatsRange(r.Since(time.Now()))
+		return RangeStatsLoadedMsg{Range: r, Stats: stats, Err: err}
+	}
+}
+
+// subjectChartWidth is the length of the longest bar in the subject chart
+const subjectChartWidth = 30
+
+// subjectTotal is one subject's count or minutes
+type subjectTotal struct {
+	name  string
+	total int
+}
+
+// sortSubjectTotals orders a per-subject map by total descending, then name
+// ascending, so renders are stable despite random map iteration
+func sortSubjectTotals(totals map[string]int) []subjectTotal {
+	entries := make([]subjectTotal, 0, len(totals))
+	for name, total := range totals {
+		entries = append(entries, subjectTotal{name: name, total: total})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].total != entries[j].total {
+			return entries[i].total > entries[j].total
+		}
+		return entries[i].name < entries[j].name
+	})
+	return entries
+}
+
+// renderSubjectChart draws a horizontal bar per subject, longest first.
+// Subjects with rated sessions show their average rating after the total.
+func renderSubjectChart(minutesBySubject map[string]int, ratingBySubject map[string]float64) string {
+	entries := sortSubjectTotals(minutesBySubject)
+	if len(entries) == 0 {
+		return ""
+	}
+
+	max := entries[0].total
+	nameWidth := 0
+	for _, e := range entries {
+		if w := lipgloss.Width(e.name); w > nameWidth {
+			nameWidth = w
+		}
+	}
+
+	var rows []string
+	for _, e := range entries {
+		length := 0
+		if max > 0 {
+			length = e.total * subjectChartWidth / max
+		}
+		if length == 0 && e.total > 0 {
+			length = 1
+		}
+		name := e.name + strings.Repeat(" ", nameWidth-lipgloss.Width(e.name))
+		bar := StreakStyle.Render(strings.Repeat("█", length))
+		row := fmt.Sprintf("  %s  %s %s", NormalStyle.Render(name), bar, HelpStyle.Render(formatMinutes(e.total)))
+		if avg, ok := ratingBySubject[e.name]; ok {
+			row += " " + StreakStyle.Render(fmt.Sprintf("★%.1f", avg))
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// weeklyGoalWidth is the number of cells in the weekly goal bar
+const weeklyGoalWidth = 20
+
+// renderWeeklyGoal shows this week's focus minutes against the weekly goal,
+// or just the minutes when no goal is set
+func renderWeeklyGoal(minutes, goal int) string {
+	if goal <= 0 {
+		return NormalStyle.Render(formatMinutes(minutes)+" so far") +
+			HelpStyle.Render(" — set a weekly goal in Settings")
+	}
+
+	filled := min(minutes*weeklyGoalWidth/goal, weeklyGoalWidth)
+	bar := StreakStyle.Render(strings.Repeat("█", filled)) +
+		HelpStyle.Render(strings.Repeat("░", weeklyGoalWidth-filled))
+	line := fmt.Sprintf("%s %s", bar, NormalStyle.Render(fmt.Sprintf("%s of %s (%d%%)",
+		formatMinutes(minutes), formatMinutes(goal), minutes*100/goal)))
+	if minutes >= goal {
+		line += " " + SuccessStyle.Render("✓ goal met")
+	}
+	return line
+}
+
+// vowRatioWidth is the number of cells in the kept-vs-broken bar
+const vowRatioWidth = 20
+
+// renderVowRatio shows completed sessions against abandoned ones as a single
+// bar, kept in green and broken in red, always vowRatioWidth cells long
+func renderVowRatio(kept, broken int) string {
+	total := kept + broken
+	if total == 0 {
+		return HelpStyle.Render(strings.Repeat("░", vowRatioWidth)) +
+			HelpStyle.Render(" — no vows sworn yet")
+	}
+
+	// Round to the nearest cell, but never hide a side that has sessions
+	keptCells := (kept*vowRatioWidth + total/2) / total
+	if kept > 0 && keptCells == 0 {
+		keptCells = 1
+	}
+	if broken > 0 && keptCells == vowRatioWidth {
+		keptCells = vowRatioWidth - 1
+	}
+
+	bar := SuccessStyle.Render(strings.Repeat("█", keptCells)) +
+		ErrorStyle.Render(strings.Repeat("█", vowRatioWidth-keptCells))
+	return fmt.Sprintf("%s %s", bar, NormalStyle.Render(fmt.Sprintf("%d%% kept", kept*100/total)))
+}
+
+// hourHistogramHeight is the number of rows in the time-of-day histogram
+const hourHistogramHeight = 6
+
+// barEighths are the partial block characters for 1/8 to 7/8 of a cell
+var barEighths = []rune("▁▂▃▄▅▆▇")
+
+// renderHourHistogram draws one column per hour of the day, two cells wide,
+// with an hour axis underneath. Columns are scaled to the busiest hour.
+func renderHourHistogram(byHour map[int]int) string {
+	max := 0
+	for _, n := range byHour {
+		if n > max {
+			max = n
+		}
+	}
+	if max == 0 {
+		return ""
+	}
+
+	var rows []string
+	for r := hourHistogramHeight - 1; r >= 0; r-- {
+		var row strings.Builder
+		for h := 0; h < 24; h++ {
+			eighths := byHour[h] * hourHistogramHeight * 8 / max
+			if eighths == 0 && byHour[h] > 0 {
+				eighths = 1 // Every hour with a session stays visible
+			}
+			switch fill := eighths - r*8; {
+			case fill >= 8:
+				row.WriteString("█ ")
+			case fill > 0:
+				row.WriteString(string(barEighths[fill-1]) + " ")
+			default:
+				row.WriteString("  ")
+			}
+		}
+		rows = append(rows, "  "+StreakStyle.Render(strings.TrimRight(row.String(), " ")))
+	}
+
+	axis := []rune(strings.Repeat(" ", 48))
+	for _, h := range []int{0, 6, 12, 18, 23} {
+		copy(axis[h*2:], []rune(fmt.Sprint(h)))
+	}
+	rows = append(rows, "  "+HelpStyle.Render(strings.TrimRight(string(axis), " ")))
+
+	return strings.Join(rows, "\n")
+}
+
+// bestHour returns the hour with the most sessions, earliest on a tie
+func bestHour(byHour map[int]int) (hour, count int) {
+	for h := 0; h < 24; h++ {
+		if byHour[h] > count {
+			hour, count = h, byHour[h]
+		}
+	}
+	return hour, count
+}
+
+// weekdayNames labels the weekday chart, Monday first to match the db buckets
+var weekdayNames = [7]string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// renderWeekdayChart draws a horizontal bar of minutes per weekday, in
+// calendar order from the week start so neglected days stand out
+func renderWeekdayChart(byWeekday [7]int, start time.Weekday) string {
+	max := 0
+	for _, n := range byWeekday {
+		if n > max {
+			max = n
+		}
+	}
+	if max == 0 {
+		return ""
+	}
+
+	// byWeekday is Monday first; rotate so the week starts on start
+	first := (int(start) + 6) % 7
+	rows := make([]string, len(weekdayNames))
+	for row := range rows {
+		i := (first + row) % 7
+		name := weekdayNames[i]
+		length := byWeekday[i] * subjectChartWidth / max
+		if length == 0 && byWeekday[i] > 0 {
+			length = 1
+		}
+		bar := StreakStyle.Render(strings.Repeat("█", length))
+		rows[row] = fmt.Sprintf("  %s  %s %s", NormalStyle.Render(name), bar, HelpStyle.Render(formatMinutes(byWeekday[i])))
+	}
+	return strings.Join(rows, "\n")
+}
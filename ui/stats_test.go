@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"Beot/db"
+)
+
+func TestSortSubjectTotals(t *testing.T) {
+	totals := map[string]int{
+		"React":   3,
+		"GoLang":  5,
+		"Writing": 3,
+		"Music":   1,
+		"Art":     3,
+	}
+
+	want := []subjectTotal{
+		{"GoLang", 5},
+		{"Art", 3},
+		{"React", 3},
+		{"Writing", 3},
+		{"Music", 1},
+	}
+
+	// Repeat to catch any dependence on map iteration order
+	for i := 0; i < 20; i++ {
+		if got := sortSubjectTotals(totals); !reflect.DeepEqual(got, want) {
+			t.Fatalf("sortSubjectTotals() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRenderSubjectChartRating(t *testing.T) {
+	got := renderSubjectChart(map[string]int{"GoLang": 50, "Music": 25}, map[string]float64{"GoLang": 4.25})
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d rows, want 2:\n%s", len(lines), got)
+	}
+	if !strings.HasSuffix(lines[0], "★4.2") {
+		t.Errorf("rated subject row = %q, want a ★4.2 suffix", lines[0])
+	}
+	if strings.Contains(lines[1], "★") {
+		t.Errorf("unrated subject row = %q, want no rating", lines[1])
+	}
+}
+
+func TestRenderHourHistogram(t *testing.T) {
+	if got := renderHourHistogram(nil); got != "" {
+		t.Errorf("empty histogram = %q, want \"\"", got)
+	}
+
+	rows := strings.Split(renderHourHistogram(map[int]int{9: 4, 14: 1}), "\n")
+	if len(rows) != hourHistogramHeight+1 {
+		t.Fatalf("got %d rows, want %d bars plus an axis", len(rows), hourHistogramHeight+1)
+	}
+
+	// Hour 9 is the busiest, so its column is full height; hour 14 still shows
+	col := func(hour int) rune { return []rune(rows[0])[2+hour*2] }
+	if col(9) != '█' {
+		t.Errorf("top of hour 9 = %q, want a full block", col(9))
+	}
+	bottom := []rune(rows[hourHistogramHeight-1])
+	if bottom[2+14*2] == ' ' {
+		t.Error("hour 14 has a session but no bar")
+	}
+}
+
+func TestBestHour(t *testing.T) {
+	hour, count := bestHour(map[int]int{7: 3, 21: 3, 10: 1})
+	if hour != 7 || count != 3 {
+		t.Errorf("bestHour = %d, %d; want 7, 3 (earliest wins a tie)", hour, count)
+	}
+}
+
+func TestRenderWeekdayChart(t *testing.T) {
+	rows := strings.Split(renderWeekdayChart([7]int{60, 0, 0, 0, 0, 0, 30}, time.Monday), "\n")
+	if len(rows) != 7 {
+		t.Fatalf("got %d rows, want 7", len(rows))
+	}
+	if !strings.Contains(rows[0], "Mon") || !strings.Contains(rows[6], "Sun") {
+		t.Errorf("rows are not Monday first: %q … %q", rows[0], rows[6])
+	}
+	if strings.Contains(rows[1], "█") {
+		t.Error("a day with no minutes should have no bar")
+	}
+
+	rows = strings.Split(renderWeekdayChart([7]int{60, 0, 0, 0, 0, 0, 30}, time.Sunday), "\n")
+	if !strings.Contains(rows[0], "Sun") || !strings.Contains(rows[0], "30m") || !strings.Contains(rows[6], "Sat") {
+		t.Errorf("rows are not Sunday first: %q … %q", rows[0], rows[6])
+	}
+}
+
+func TestFormatTotals(t *testing.T) {
+	tests := []struct {
+		stats db.SessionStats
+		want  string
+	}{
+		{db.SessionStats{CompletedSessions: 1, TotalMinutes: 25}, "1 session · 25m"},
+		{db.SessionStats{CompletedSessions: 42, TotalMinutes: 17*60 + 40}, "42 sessions · 17h"},
+	}
+	for _, tt := range tests {
+		if got := formatTotals(tt.stats); got != tt.want {
+			t.Errorf("formatTotals(%+v) = %q, want %q", tt.stats, got, tt.want)
+		}
+	}
+}
+
+func TestRenderWeeklyGoal(t *testing.T) {
+	if got := renderWeeklyGoal(90, 0); !strings.HasPrefix(got, "1h 30m so far") {
+		t.Errorf("no goal: got %q", got)
+	}
+
+	got := renderWeeklyGoal(150, 600)
+	if want := strings.Repeat("█", 5) + strings.Repeat("░", 15) + " 2h 30m of 10h 0m (25%)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Overshooting fills the bar without spilling past it
+	if got := renderWeeklyGoal(700, 600); !strings.HasPrefix(got, strings.Repeat("█", weeklyGoalWidth)+" ") || !strings.Contains(got, "goal met") {
+		t.Errorf("goal exceeded: got %q", got)
+	}
+}
+
+func TestRenderVowRatio(t *testing.T) {
+	if got := renderVowRatio(0, 0); !strings.HasPrefix(got, strings.Repeat("░", vowRatioWidth)) || !strings.Contains(got, "no vows") {
+		t.Errorf("no sessions: got %q", got)
+	}
+
+	if got, want := renderVowRatio(3, 1), strings.Repeat("█", vowRatioWidth)+" 75% kept"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// A single broken vow among many still shows, and the bar keeps its width
+	got := renderVowRatio(99, 1)
+	if n := strings.Count(got, "█"); n != vowRatioWidth {
+		t.Errorf("bar should be %d cells, got %d in %q", vowRatioWidth, n, got)
+	}
+}
+
+func TestStatsRangeSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		r    statsRange
+		want time.Time
+	}{
+		{rangeAllTime, time.Time{}},
+		{rangeLast7Days, time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local)},
+		{rangeLast30Days, time.Date(2026, 2, 9, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Since(now); !got.Equal(tt.want) {
+			t.Errorf("%s: Since = %v, want %v", tt.r.Label(), got, tt.want)
+		}
+	}
+	if got := rangeLast30Days.Next(); got != rangeAllTime {
+		t.Errorf("Next after 30 days = %s, want All Time", got.Label())
+	}
+}
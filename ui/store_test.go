@@ -0,0 +1,21 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"Beot/db"
+)
+
+// useTempStore makes an empty local store in a temporary directory the
+// active backend for the rest of the test
+func useTempStore(t *testing.T) *db.LocalStore {
+	t.Helper()
+	store, err := db.OpenLocalStore(filepath.Join(t.TempDir(), "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Use(store)
+	t.Cleanup(func() { db.Use(db.MongoStore{}) })
+	return store
+}
@@ -2,100 +2,418 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/lipgloss"
+
+	"Beot/db"
 )
 
+// Theme bundles the colour palette used to build every style
+type Theme struct {
+	Name       string
+	Primary    lipgloss.Color
+	Secondary  lipgloss.Color
+	Muted      lipgloss.Color
+	Gold       lipgloss.Color
+	Success    lipgloss.Color
+	Warning    lipgloss.Color
+	Danger     lipgloss.Color
+	OldEnglish lipgloss.Color
+	Banner     []rgb             // Gradient stops for the ASCII banner
+	Progress   [2]lipgloss.Color // Progress bar gradient, start to finish (hex only)
+}
+
+// Themes lists the built-in palettes in menu order
+var Themes = []Theme{
+	{
+		Name:       "anglo-saxon",
+		Primary:    lipgloss.Color("#E6DCC7"), // Parchment
+		Secondary:  lipgloss.Color("#A9A393"), // Ash
+		Muted:      lipgloss.Color("#7C776C"), // Muted/Helper
+		Gold:       lipgloss.Color("#DAA520"), // Anglo-Saxon Gold
+		Success:    lipgloss.Color("82"),      // Green
+		Warning:    lipgloss.Color("214"),     // Orange
+		Danger:     lipgloss.Color("196"),     // Red
+		OldEnglish: lipgloss.Color("#DAA520"), // Gold
+		Banner: []rgb{
+			{0x7E, 0xB8, 0xDA}, // Steel blue
+			{0x9B, 0x7E, 0xC8}, // Amethyst
+			{0xDA, 0xA5, 0x20}, // Anglo-Saxon gold
+		},
+		Progress: [2]lipgloss.Color{"#4A3728", "#C9A84C"}, // Oak to gold
+	},
+	{
+		Name:       "anglo-saxon-light",
+		Primary:    lipgloss.Color("#3B2F1E"), // Oak gall ink
+		Secondary:  lipgloss.Color("#5C5446"), // Soot
+		Muted:      lipgloss.Color("#857D6E"), // Faded ink
+		Gold:       lipgloss.Color("#8B6508"), // Burnished gold
+		Success:    lipgloss.Color("28"),      // Dark green
+		Warning:    lipgloss.Color("130"),     // Dark orange
+		Danger:     lipgloss.Color("124"),     // Dark red
+		OldEnglish: lipgloss.Color("#8B6508"), // Burnished gold
+		Banner: []rgb{
+			{0x2E, 0x5A, 0x7A}, // Deep steel
+			{0x5B, 0x3E, 0x88}, // Dark amethyst
+			{0x8B, 0x65, 0x08}, // Burnished gold
+		},
+		Progress: [2]lipgloss.Color{"#C9B99A", "#8B6508"}, // Vellum to burnished gold
+	},
+	{
+		Name:       "high-contrast",
+		Primary:    lipgloss.Color("#FFFFFF"),
+		Secondary:  lipgloss.Color("#FFFF00"),
+		Muted:      lipgloss.Color("#00FFFF"),
+		Gold:       lipgloss.Color("#FFD700"),
+		Success:    lipgloss.Color("#00FF00"),
+		Warning:    lipgloss.Color("#FF8C00"),
+		Danger:     lipgloss.Color("#FF0000"),
+		OldEnglish: lipgloss.Color("#FFD700"),
+		Banner: []rgb{
+			{0xFF, 0xFF, 0xFF},
+			{0xFF, 0xD7, 0x00},
+		},
+		Progress: [2]lipgloss.Color{"#FFFF00", "#FFD700"},
+	},
+	{
+		Name:       "monochrome",
+		Primary:    lipgloss.Color("#FFFFFF"),
+		Secondary:  lipgloss.Color("#BBBBBB"),
+		Muted:      lipgloss.Color("#777777"),
+		Gold:       lipgloss.Color("#FFFFFF"),
+		Success:    lipgloss.Color("#FFFFFF"),
+		Warning:    lipgloss.Color("#BBBBBB"),
+		Danger:     lipgloss.Color("#FFFFFF"),
+		OldEnglish: lipgloss.Color("#DDDDDD"),
+		Banner: []rgb{
+			{0x88, 0x88, 0x88},
+			{0xFF, 0xFF, 0xFF},
+		},
+		Progress: [2]lipgloss.Color{"#555555", "#FFFFFF"},
+	},
+}
+
+// ActiveTheme is the palette currently applied to the style vars; while
+// dimmed it is the darkened copy of baseTheme
+var ActiveTheme = Themes[0]
+
+// baseTheme is the chosen theme before any night-mode dimming
+var baseTheme = Themes[0]
+
+// Dimmed reports whether the night-mode palette is applied
+var Dimmed bool
+
+// dimFactor is how far night mode darkens each colour towards black
+const dimFactor = 0.45
+
+// nightEndsHour is when night mode switches off again in the morning
+const nightEndsHour = 6
+
 var (
-	Primary   = lipgloss.Color("#E6DCC7") // Parchment
-	Secondary = lipgloss.Color("#A9A393") // Ash
-	Muted     = lipgloss.Color("#7C776C") // Muted/Helper
-	Gold      = lipgloss.Color("#DAA520") // Anglo-Saxon Gold
-	Success   = lipgloss.Color("82")      // Green
-	Warning   = lipgloss.Color("214")     // Orange
-	Danger    = lipgloss.Color("196")     // Red
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Muted     lipgloss.Color
+	Gold      lipgloss.Color
+	Success   lipgloss.Color
+	Warning   lipgloss.Color
+	Danger    lipgloss.Color
 )
 
 // Text styles
 var (
+	TitleStyle    lipgloss.Style
+	SubtitleStyle lipgloss.Style
+	HelpStyle     lipgloss.Style
+	SelectedStyle lipgloss.Style
+	NormalStyle   lipgloss.Style
+	SuccessStyle  lipgloss.Style
+	ErrorStyle    lipgloss.Style
+	StreakStyle   lipgloss.Style
+	VersionStyle  lipgloss.Style
+	WarningStyle  lipgloss.Style
+)
+
+// Layout styles
+var (
+	BoxStyle      lipgloss.Style
+	CenteredStyle lipgloss.Style
+	TimerStyle    lipgloss.Style
+	StatusStyle   lipgloss.Style
+
+	// IconStyle ensures all icons take up the same width
+	IconStyle lipgloss.Style
+)
+
+// QuoteStyle for displaying motivational quotes
+var QuoteStyle lipgloss.Style
+
+// OldEnglishStyle for Old English text - golden/amber color
+var OldEnglishStyle lipgloss.Style
+
+// ModernEnglishStyle for modern translation
+var ModernEnglishStyle lipgloss.Style
+
+func init() {
+	ApplyTheme(os.Getenv("BEOT_THEME"))
+}
+
+// DetectAndApplyBackground switches to the light palette when the terminal
+// has a light background. BEOT_BACKGROUND=light|dark overrides detection,
+// and an explicit BEOT_THEME, then a theme in the config file, always wins.
+func DetectAndApplyBackground() {
+	if os.Getenv("BEOT_THEME") != "" {
+		return
+	}
+	if theme := db.ConfigTheme(); theme != "" {
+		ApplyTheme(theme)
+		return
+	}
+
+	dark := true
+	switch strings.ToLower(os.Getenv("BEOT_BACKGROUND")) {
+	case "light":
+		dark = false
+	case "dark":
+		dark = true
+	default:
+		dark = lipgloss.HasDarkBackground()
+	}
+
+	if !dark {
+		ApplyTheme("anglo-saxon-light")
+	}
+}
+
+// ThemeByName looks up a built-in theme, falling back to the default
+func ThemeByName(name string) Theme {
+	for _, t := range Themes {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return Themes[0]
+}
+
+// ApplyTheme sets the palette and rebuilds every style from it.
+// Unknown names fall back to the default anglo-saxon theme.
+func ApplyTheme(name string) {
+	setTheme(ThemeByName(name))
+}
+
+// NextTheme applies the theme after the active one and returns its name
+func NextTheme() string {
+	for i, t := range Themes {
+		if t.Name == baseTheme.Name {
+			setTheme(Themes[(i+1)%len(Themes)])
+			return ActiveTheme.Name
+		}
+	}
+	setTheme(Themes[0])
+	return ActiveTheme.Name
+}
+
+// SetDimmed switches the night-mode palette on or off, keeping the theme
+func SetDimmed(on bool) {
+	if on == Dimmed {
+		return
+	}
+	Dimmed = on
+	setTheme(baseTheme)
+}
+
+func setTheme(t Theme) {
+	baseTheme = t
+	if Dimmed {
+		t = dimTheme(t)
+	}
+	ActiveTheme = t
+
+	Primary = t.Primary
+	Secondary = t.Secondary
+	Muted = t.Muted
+	Gold = t.Gold
+	Success = t.Success
+	Warning = t.Warning
+	Danger = t.Danger
+
+	buildStyles()
+}
+
+// buildStyles recreates the style vars from the current palette
+func buildStyles() {
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Primary)
+		Bold(true).
+		Foreground(Primary)
 
 	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(Secondary)
+		Foreground(Secondary)
 
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(Muted)
+		Foreground(Muted)
 
 	SelectedStyle = lipgloss.NewStyle().
-			Foreground(Primary).
-			Bold(true)
+		Foreground(Primary).
+		Bold(true)
 
 	NormalStyle = lipgloss.NewStyle().
-			Foreground(Secondary)
+		Foreground(Secondary)
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(Success).
-			Bold(true)
+		Foreground(Success).
+		Bold(true)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(Danger).
-			Bold(true)
+		Foreground(Danger).
+		Bold(true)
 
 	StreakStyle = lipgloss.NewStyle().
-			Foreground(Gold).
-			Bold(true)
+		Foreground(Gold).
+		Bold(true)
 
 	VersionStyle = lipgloss.NewStyle().
-			Foreground(Muted)
+		Foreground(Muted)
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(Warning)
-)
+		Foreground(Warning)
 
-// Layout styles
-var (
 	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(Primary).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(Primary).
+		Padding(1, 2)
 
 	CenteredStyle = lipgloss.NewStyle().
-			Align(lipgloss.Center)
+		Align(lipgloss.Center)
 
 	TimerStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Primary).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(Primary).
+		MarginBottom(1)
 
 	StatusStyle = lipgloss.NewStyle().
-			Foreground(Secondary)
+		Foreground(Secondary)
 
-	// IconStyle ensures all icons take up the same width
 	IconStyle = lipgloss.NewStyle().Width(3)
-)
 
-// QuoteStyle for displaying motivational quotes
-var QuoteStyle = lipgloss.NewStyle().
-	Foreground(Secondary).
-	Italic(true).
-	Width(70).
-	MarginLeft(4)
+	QuoteStyle = lipgloss.NewStyle().
+		Foreground(Secondary).
+		Italic(true).
+		Width(70).
+		MarginLeft(4)
+
+	OldEnglishStyle = lipgloss.NewStyle().
+		Foreground(ActiveTheme.OldEnglish).
+		Italic(true).
+		Width(70).
+		MarginLeft(4)
+
+	ModernEnglishStyle = lipgloss.NewStyle().
+		Foreground(Secondary).
+		Width(70).
+		MarginLeft(4)
+}
 
-// OldEnglishStyle for Old English text - golden/amber color
-var OldEnglishStyle = lipgloss.NewStyle().
-	Foreground(lipgloss.Color("#DAA520")). // Gold
-	Italic(true).
-	Width(70).
-	MarginLeft(4)
+// dimTheme returns a darker, lower-contrast copy of a theme for night mode
+func dimTheme(t Theme) Theme {
+	dim := t
+	for _, c := range []*lipgloss.Color{&dim.Primary, &dim.Secondary, &dim.Muted, &dim.Gold,
+		&dim.Success, &dim.Warning, &dim.Danger, &dim.OldEnglish, &dim.Progress[0], &dim.Progress[1]} {
+		*c = dimColor(*c)
+	}
+	dim.Banner = make([]rgb, len(t.Banner))
+	for i, c := range t.Banner {
+		dim.Banner[i] = lerpRGB(c, rgb{}, dimFactor)
+	}
+	return dim
+}
 
-// ModernEnglishStyle for modern translation
-var ModernEnglishStyle = lipgloss.NewStyle().
-	Foreground(Secondary).
-	Width(70).
-	MarginLeft(4)
+// dimColor darkens a "#rrggbb" or ANSI 256 colour, returning it as hex
+func dimColor(c lipgloss.Color) lipgloss.Color {
+	v, ok := parseColor(c)
+	if !ok {
+		return c
+	}
+	d := lerpRGB(v, rgb{}, dimFactor)
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", d.r, d.g, d.b))
+}
+
+// ansiBasic holds the xterm values of the 16 standard ANSI colours
+var ansiBasic = [16]rgb{
+	{0x00, 0x00, 0x00}, {0x80, 0x00, 0x00}, {0x00, 0x80, 0x00}, {0x80, 0x80, 0x00},
+	{0x00, 0x00, 0x80}, {0x80, 0x00, 0x80}, {0x00, 0x80, 0x80}, {0xc0, 0xc0, 0xc0},
+	{0x80, 0x80, 0x80}, {0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+	{0x00, 0x00, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff}, {0xff, 0xff, 0xff},
+}
+
+// parseColor converts a "#rrggbb" or ANSI 256 colour to its RGB value
+func parseColor(c lipgloss.Color) (rgb, bool) {
+	s := string(c)
+	if strings.HasPrefix(s, "#") && len(s) == 7 {
+		n, err := strconv.ParseUint(s[1:], 16, 32)
+		if err != nil {
+			return rgb{}, false
+		}
+		return rgb{uint8(n >> 16), uint8(n >> 8), uint8(n)}, true
+	}
+
+	n, err := strconv.Atoi(s)
+	switch {
+	case err != nil || n < 0 || n > 255:
+		return rgb{}, false
+	case n < 16:
+		return ansiBasic[n], true
+	case n < 232:
+		// 6×6×6 colour cube
+		level := func(i int) uint8 {
+			if i == 0 {
+				return 0
+			}
+			return uint8(55 + 40*i)
+		}
+		n -= 16
+		return rgb{level(n / 36), level(n / 6 % 6), level(n % 6)}, true
+	default:
+		grey := uint8(8 + 10*(n-232))
+		return rgb{grey, grey, grey}, true
+	}
+}
+
+// ParseClock parses a local time of day as "HH:MM", returning minutes after midnight
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("time must look like HH:MM, e.g. 22:30")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// NightModeActive reports whether now falls between the "HH:MM" start
+// and nightEndsHour the next morning. An empty or invalid start is off.
+func NightModeActive(after string, now time.Time) bool {
+	start, err := ParseClock(after)
+	if after == "" || err != nil {
+		return false
+	}
+	clock := now.Hour()*60 + now.Minute()
+	end := nightEndsHour * 60
+	if start < end {
+		// Starts after midnight, e.g. 01:00 until 06:00
+		return clock >= start && clock < end
+	}
+	return clock >= start || clock < end
+}
+
+// NewProgressBar returns a progress bar using the active theme's gradient
+func NewProgressBar() progress.Model {
+	from, to := ActiveTheme.Progress[0], ActiveTheme.Progress[1]
+	bar := progress.New(progress.WithGradient(string(from), string(to)))
+	bar.Width = 80
+	return bar
+}
 
 // RenderHeader renders just the Bēot title (compact, for timer etc.)
 func RenderHeader() string {
@@ -115,12 +433,6 @@ var bannerLines = []string{
 
 type rgb struct{ r, g, b uint8 }
 
-var bannerGradient = []rgb{
-	{0x7E, 0xB8, 0xDA}, // Steel blue
-	{0x9B, 0x7E, 0xC8}, // Amethyst
-	{0xDA, 0xA5, 0x20}, // Anglo-Saxon gold
-}
-
 func lerpRGB(a, b rgb, t float64) rgb {
 	return rgb{
 		r: uint8(float64(a.r) + t*(float64(b.r)-float64(a.r))),
@@ -130,8 +442,9 @@ func lerpRGB(a, b rgb, t float64) rgb {
 }
 
 func gradientAt(pos, total int) lipgloss.Color {
-	if total <= 1 {
-		return lipgloss.Color("#daa520")
+	bannerGradient := ActiveTheme.Banner
+	if total <= 1 || len(bannerGradient) < 2 {
+		return Gold
 	}
 	t := float64(pos) / float64(total-1)
 
@@ -172,6 +485,57 @@ func RenderBanner() string {
 	return b.String()
 }
 
+// bigGlyphs holds block-letter digits for the large countdown, each
+// bigGlyphHeight rows tall
+var bigGlyphs = map[rune][]string{
+	'0': {"██████", "██  ██", "██  ██", "██  ██", "██████"},
+	'1': {"████  ", "  ██  ", "  ██  ", "  ██  ", "██████"},
+	'2': {"██████", "    ██", "██████", "██    ", "██████"},
+	'3': {"██████", "    ██", "██████", "    ██", "██████"},
+	'4': {"██  ██", "██  ██", "██████", "    ██", "    ██"},
+	'5': {"██████", "██    ", "██████", "    ██", "██████"},
+	'6': {"██████", "██    ", "██████", "██  ██", "██████"},
+	'7': {"██████", "    ██", "    ██", "    ██", "    ██"},
+	'8': {"██████", "██  ██", "██████", "██  ██", "██████"},
+	'9': {"██████", "██  ██", "██████", "    ██", "██████"},
+	':': {"  ", "██", "  ", "██", "  "},
+}
+
+const bigGlyphHeight = 5
+
+// RenderBigTime renders MM:SS in block digits with the banner gradient,
+// for reading the timer from across the room
+func RenderBigTime(mm, ss int) string {
+	text := fmt.Sprintf("%02d:%02d", mm, ss)
+
+	rows := make([]string, bigGlyphHeight)
+	for i, ch := range text {
+		for r := range rows {
+			if i > 0 {
+				rows[r] += "  "
+			}
+			rows[r] += bigGlyphs[ch][r]
+		}
+	}
+
+	width := len([]rune(rows[0]))
+	var b strings.Builder
+	for i, row := range rows {
+		for j, ch := range []rune(row) {
+			if ch == ' ' {
+				b.WriteRune(' ')
+			} else {
+				style := lipgloss.NewStyle().Foreground(gradientAt(j, width)).Bold(true)
+				b.WriteString(style.Render(string(ch)))
+			}
+		}
+		if i < len(rows)-1 {
+			b.WriteRune('\n')
+		}
+	}
+	return b.String()
+}
+
 // RenderQuote renders a quote with optional source
 func RenderQuote(text, source string) string {
 	quote := QuoteStyle.Render("\"" + text + "\"")
@@ -181,6 +545,27 @@ func RenderQuote(text, source string) string {
 	return quote
 }
 
+// RenderQuoteLimited renders a quote cut to at most max characters, at a
+// word boundary where possible, with a hint that expandKey shows the rest.
+// A max of 0 or less, or a quote that fits, renders in full.
+func RenderQuoteLimited(text, source string, max int, expandKey string) string {
+	runes := []rune(text)
+	if max <= 0 || len(runes) <= max {
+		return RenderQuote(text, source)
+	}
+	cut := runes[:max]
+	// Break at the last space in the back half, counted in runes so long
+	// runs of ǣ or þ don't pull the break point forward
+	for i := len(cut) - 1; i > max/2; i-- {
+		if cut[i] == ' ' || cut[i] == '\n' {
+			cut = cut[:i]
+			break
+		}
+	}
+	text = strings.TrimRight(string(cut), " \n.,;:") + "…"
+	return RenderQuote(text, source) + "\n    " + HelpStyle.Render("("+expandKey+" to read full)")
+}
+
 // RenderPoem renders a poem with Old English and Modern English side by side
 func RenderPoem(oldEnglish, modernEnglish, source, lineRef string) string {
 	oe := OldEnglishStyle.Render(oldEnglish)
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderBigTime(t *testing.T) {
+	got := strings.Split(RenderBigTime(0, 5), "\n")
+	if len(got) != bigGlyphHeight {
+		t.Fatalf("got %d rows, want %d", len(got), bigGlyphHeight)
+	}
+
+	// Leading zeros are drawn, not dropped
+	want := "██████  ██████      ██████  ██████"
+	if got[0] != want {
+		t.Errorf("top row = %q, want %q", got[0], want)
+	}
+	for i, row := range got {
+		if n := len([]rune(row)); n != len([]rune(want)) {
+			t.Errorf("row %d is %d wide, want %d", i, n, len([]rune(want)))
+		}
+	}
+}
+
+func TestNightModeActive(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 1, 10, hour, min, 0, 0, time.Local)
+	}
+	tests := []struct {
+		after string
+		now   time.Time
+		want  bool
+	}{
+		{"", at(23, 0), false},
+		{"nonsense", at(23, 0), false},
+		{"22:30", at(22, 29), false},
+		{"22:30", at(22, 30), true},
+		{"22:30", at(2, 0), true},
+		{"22:30", at(6, 0), false},
+		{"22:30", at(12, 0), false},
+		{"00:00", at(23, 59), false},
+		{"00:00", at(0, 15), true},
+		{"01:00", at(0, 30), false},
+		{"01:00", at(5, 59), true},
+	}
+	for _, tt := range tests {
+		if got := NightModeActive(tt.after, tt.now); got != tt.want {
+			t.Errorf("NightModeActive(%q, %s) = %v, want %v", tt.after, tt.now.Format("15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestSetDimmedKeepsTheme(t *testing.T) {
+	ApplyTheme("anglo-saxon")
+	defer SetDimmed(false)
+
+	SetDimmed(true)
+	if ActiveTheme.Name != "anglo-saxon" || ActiveTheme.Primary != "#7e796d" {
+		t.Errorf("dimmed theme = %s %s, want anglo-saxon #7e796d", ActiveTheme.Name, ActiveTheme.Primary)
+	}
+	if ActiveTheme.Success == Themes[0].Success {
+		t.Error("ANSI colours should be dimmed too")
+	}
+
+	// Cycling themes at night stays dimmed
+	if name := NextTheme(); name != "anglo-saxon-light" || !Dimmed {
+		t.Errorf("NextTheme() = %s, dimmed %v; want anglo-saxon-light, dimmed", name, Dimmed)
+	}
+	ApplyTheme("anglo-saxon")
+
+	SetDimmed(false)
+	if ActiveTheme.Primary != Themes[0].Primary {
+		t.Errorf("undimmed primary = %s, want %s", ActiveTheme.Primary, Themes[0].Primary)
+	}
+}
+
+func TestRenderQuoteLimited(t *testing.T) {
+	text := "Wyrd oft nereð unfǣgne eorl, þonne his ellen dēah"
+
+	if got := RenderQuoteLimited(text, "", 0, "e"); got != RenderQuote(text, "") {
+		t.Errorf("no limit should render in full, got %q", got)
+	}
+	if got := RenderQuoteLimited(text, "", len([]rune(text)), "e"); strings.Contains(got, "read full") {
+		t.Errorf("a quote that fits should not be cut, got %q", got)
+	}
+
+	got := RenderQuoteLimited(text, "Beowulf", 30, "e")
+	if !strings.Contains(got, "unfǣgne eorl…") {
+		t.Errorf("should cut at a word boundary with an ellipsis, got %q", got)
+	}
+	if !strings.Contains(got, "(e to read full)") || !strings.Contains(got, "Beowulf") {
+		t.Errorf("should keep the source and hint at e, got %q", got)
+	}
+
+	// The only space sits in the front half by runes, though not by bytes
+	wide := "ǣǣǣǣǣǣǣǣ ǣǣǣǣǣǣǣǣǣǣǣǣ"
+	if got := RenderQuoteLimited(wide, "", 20, "e"); !strings.Contains(got, "ǣǣǣǣǣǣǣǣ ǣǣǣǣǣǣǣǣǣǣǣ…") {
+		t.Errorf("should cut at the rune limit when no space is in the back half, got %q", got)
+	}
+}
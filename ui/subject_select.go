@@ -2,21 +2,36 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"Beot/db"
 )
 
 type SubjectSelectModel struct {
-	subjects  []db.Subject
-	cursor    int
-	adding    bool
-	textInput textinput.Model
-	iconInput textinput.Model
-	inputFocus int
-	err       error
+	subjects     []db.Subject
+	sessions     map[string]int // Completed sessions per subject name
+	cursor       int
+	adding       bool
+	textInput    textinput.Model
+	iconInput    textinput.Model
+	minutesInput textinput.Model
+	inputFocus   int    // 0 = name, 1 = icon, 2 = minutes
+	formErr      string // Validation problem shown on the add form
+	showArchived bool   // List archived subjects too, so they can be restored
+	clearing     bool   // Asking whether to delete the selected subject's quotes
+	notice       string // Result of the last quote clear
+	err          error
+
+	// Order writes go one at a time; moves made while one is saving are
+	// written together once it finishes
+	savingOrder bool
+	orderDirty  bool
 }
 
 func NewSubjectSelectModel() SubjectSelectModel {
@@ -27,27 +42,37 @@ func NewSubjectSelectModel() SubjectSelectModel {
 
 	ii := textinput.New()
 	ii.Placeholder = "Icon (e.g., 🔷)"
-	ii.CharLimit = 4
+	ii.CharLimit = 16 // Room for ZWJ sequences; validated on submit
 	ii.Width = 10
 
+	mi := textinput.New()
+	mi.Placeholder = "Minutes (optional)"
+	mi.CharLimit = 3
+	mi.Width = 20
+
 	return SubjectSelectModel{
-		textInput: ti,
-		iconInput: ii,
+		textInput:    ti,
+		iconInput:    ii,
+		minutesInput: mi,
 	}
 }
 
 func (m *SubjectSelectModel) LoadSubjects() tea.Cmd {
+	includeArchived := m.showArchived
 	return func() tea.Msg {
-		subjects, err := db.GetAllSubjects()
+		subjects, err := db.GetSubjects(includeArchived)
 		if err != nil {
 			return SubjectsLoadedMsg{Err: err}
 		}
-		return SubjectsLoadedMsg{Subjects: subjects}
+		// Counts are a nudge, not essential; the list still shows without them
+		sessions, _ := db.GetSessionsBySubject()
+		return SubjectsLoadedMsg{Subjects: subjects, Sessions: sessions}
 	}
 }
 
 type SubjectsLoadedMsg struct {
 	Subjects []db.Subject
+	Sessions map[string]int // Completed sessions per subject name
 	Err      error
 }
 
@@ -60,6 +85,26 @@ type SubjectAddedMsg struct {
 	Err     error
 }
 
+type SubjectMovedMsg struct {
+	Err error
+}
+
+type SubjectArchivedMsg struct {
+	Err error
+}
+
+// SubjectStrictQuotesMsg is sent after a subject's strict quote mode is toggled
+type SubjectStrictQuotesMsg struct {
+	Err error
+}
+
+// SubjectQuotesClearedMsg reports how many of a subject's quotes were deleted
+type SubjectQuotesClearedMsg struct {
+	Subject string
+	Deleted int64
+	Err     error
+}
+
 func (m SubjectSelectModel) Init() tea.Cmd {
 	return m.LoadSubjects()
 }
@@ -71,6 +116,11 @@ func (m SubjectSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.Err
 		} else {
 			m.subjects = msg.Subjects
+			m.sessions = msg.Sessions
+			// Archiving can shorten the list under the cursor
+			if m.cursor >= len(m.subjects) {
+				m.cursor = max(len(m.subjects)-1, 0)
+			}
 		}
 		return m, nil
 
@@ -80,19 +130,66 @@ func (m SubjectSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.subjects = append(m.subjects, *msg.Subject)
 			m.adding = false
-			m.textInput.Reset()
-			m.iconInput.Reset()
+			m.resetForm()
+		}
+		return m, nil
+
+	case SubjectMovedMsg:
+		m.savingOrder = false
+		if msg.Err != nil {
+			m.err = msg.Err
+		}
+		if m.orderDirty {
+			return m, m.saveOrder()
+		}
+		return m, nil
+
+	case SubjectQuotesClearedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
 		}
+		m.notice = fmt.Sprintf("Deleted %d %s quote(s).", msg.Deleted, msg.Subject)
 		return m, nil
 
+	case SubjectArchivedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		return m, m.LoadSubjects()
+
+	case SubjectStrictQuotesMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		return m, m.LoadSubjects()
+
 	case tea.KeyMsg:
 		if m.adding {
 			return m.handleAddingInput(msg)
 		}
 
+		if m.clearing {
+			switch msg.String() {
+			case "y":
+				m.clearing = false
+				return m, clearSubjectQuotesCmd(m.subjects[m.cursor].Name)
+			case "n", "esc":
+				m.clearing = false
+			}
+			return m, nil
+		}
+
+		m.notice = ""
 		switch msg.String() {
 		case "esc", "q":
 			return m, func() tea.Msg { return BackToMenuMsg{} }
+		case "D":
+			if m.cursor < len(m.subjects) {
+				m.clearing = true
+			}
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
@@ -101,8 +198,24 @@ func (m SubjectSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor < len(m.subjects)-1 {
 				m.cursor++
 			}
+		case "shift+up", "K":
+			return m.moveSubject(-1)
+		case "shift+down", "J":
+			return m.moveSubject(1)
+		case "x":
+			if m.cursor < len(m.subjects) {
+				return m, m.toggleArchived()
+			}
+		case "o":
+			if m.cursor < len(m.subjects) {
+				return m, m.toggleStrictQuotes()
+			}
+		case "v":
+			m.showArchived = !m.showArchived
+			m.cursor = 0
+			return m, m.LoadSubjects()
 		case "enter", " ":
-			if len(m.subjects) > 0 && m.cursor < len(m.subjects) {
+			if m.cursor < len(m.subjects) && !m.subjects[m.cursor].Archived {
 				return m, func() tea.Msg {
 					return SubjectSelectedMsg{Subject: m.subjects[m.cursor]}
 				}
@@ -112,35 +225,40 @@ func (m SubjectSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.textInput.Focus()
 			m.inputFocus = 0
 			return m, textinput.Blink
+		default:
+			// Any other letter jumps to the next subject starting with it
+			names := make([]string, len(m.subjects))
+			for i, s := range m.subjects {
+				names[i] = s.Name
+			}
+			m.cursor, _ = jumpToLetter(names, m.cursor, msg.String())
 		}
 	}
 
 	return m, nil
 }
 
+// clearSubjectQuotesCmd deletes every quote tagged with the subject; general
+// quotes are untouched
+func clearSubjectQuotesCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		deleted, err := db.DeleteQuotesBySubject(name)
+		return SubjectQuotesClearedMsg{Subject: name, Deleted: deleted, Err: err}
+	}
+}
+
 func (m SubjectSelectModel) handleAddingInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
 		m.adding = false
-		m.textInput.Reset()
-		m.iconInput.Reset()
+		m.resetForm()
 		return m, nil
 	case "tab":
-		if m.inputFocus == 0 {
-			m.inputFocus = 1
-			m.textInput.Blur()
-			m.iconInput.Focus()
-		} else {
-			m.inputFocus = 0
-			m.iconInput.Blur()
-			m.textInput.Focus()
-		}
+		m.focusInput((m.inputFocus + 1) % 3)
 		return m, nil
 	case "enter":
-		if m.inputFocus == 0 {
-			m.inputFocus = 1
-			m.textInput.Blur()
-			m.iconInput.Focus()
+		if m.inputFocus < 2 {
+			m.focusInput(m.inputFocus + 1)
 			return m, nil
 		}
 		// Submit the subject
@@ -148,25 +266,121 @@ func (m SubjectSelectModel) handleAddingInput(msg tea.KeyMsg) (tea.Model, tea.Cm
 		if name == "" {
 			return m, nil
 		}
-		icon := m.iconInput.Value()
+		icon := strings.TrimSpace(m.iconInput.Value())
 		if icon == "" {
 			icon = "📚"
 		}
+		if err := validateIcon(icon); err != nil {
+			m.formErr = err.Error()
+			m.focusInput(1)
+			return m, nil
+		}
+		minutes := 0
+		if v := strings.TrimSpace(m.minutesInput.Value()); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				m.formErr = "Minutes must be a whole number above 0, or empty for the default"
+				m.focusInput(2)
+				return m, nil
+			}
+			minutes = n
+		}
+		m.formErr = ""
 		return m, func() tea.Msg {
-			subject, err := db.AddSubject(name, icon)
+			subject, err := db.AddSubjectWithDuration(name, icon, minutes)
 			return SubjectAddedMsg{Subject: subject, Err: err}
 		}
 	}
 
 	var cmd tea.Cmd
-	if m.inputFocus == 0 {
+	switch m.inputFocus {
+	case 0:
 		m.textInput, cmd = m.textInput.Update(msg)
-	} else {
+	case 1:
 		m.iconInput, cmd = m.iconInput.Update(msg)
+	default:
+		m.minutesInput, cmd = m.minutesInput.Update(msg)
 	}
 	return m, cmd
 }
 
+// moveSubject shifts the highlighted subject up (-1) or down (+1) and saves the order
+func (m SubjectSelectModel) moveSubject(delta int) (tea.Model, tea.Cmd) {
+	to := m.cursor + delta
+	// Positions are within the active list, so moving is off while archived subjects show
+	if m.showArchived || to < 0 || to >= len(m.subjects) {
+		return m, nil
+	}
+	// Copy so the previous model's slice isn't mutated
+	subjects := make([]db.Subject, len(m.subjects))
+	copy(subjects, m.subjects)
+	subjects[m.cursor], subjects[to] = subjects[to], subjects[m.cursor]
+	m.subjects = subjects
+	m.cursor = to
+
+	if m.savingOrder {
+		m.orderDirty = true
+		return m, nil
+	}
+	return m, m.saveOrder()
+}
+
+// saveOrder writes the list as shown in one go, so the stored order always
+// matches what's on screen however quickly subjects are moved
+func (m *SubjectSelectModel) saveOrder() tea.Cmd {
+	ids := make([]primitive.ObjectID, len(m.subjects))
+	for i, s := range m.subjects {
+		ids[i] = s.ID
+	}
+	m.savingOrder, m.orderDirty = true, false
+	return func() tea.Msg {
+		return SubjectMovedMsg{Err: db.SetSubjectOrder(ids)}
+	}
+}
+
+// toggleStrictQuotes switches the highlighted subject between its own quotes
+// only and its own plus general ones
+func (m SubjectSelectModel) toggleStrictQuotes() tea.Cmd {
+	subject := m.subjects[m.cursor]
+	return func() tea.Msg {
+		return SubjectStrictQuotesMsg{Err: db.SetSubjectStrictQuotes(subject.ID, !subject.StrictQuotes)}
+	}
+}
+
+// toggleArchived archives the highlighted subject, or restores it if already archived
+func (m SubjectSelectModel) toggleArchived() tea.Cmd {
+	subject := m.subjects[m.cursor]
+	return func() tea.Msg {
+		return SubjectArchivedMsg{Err: db.SetSubjectArchived(subject.ID, !subject.Archived)}
+	}
+}
+
+// focusInput moves focus to the given form field
+func (m *SubjectSelectModel) focusInput(i int) {
+	m.inputFocus = i
+	m.textInput.Blur()
+	m.iconInput.Blur()
+	m.minutesInput.Blur()
+	switch i {
+	case 0:
+		m.textInput.Focus()
+	case 1:
+		m.iconInput.Focus()
+	default:
+		m.minutesInput.Focus()
+	}
+}
+
+// resetForm clears all add-subject inputs
+func (m *SubjectSelectModel) resetForm() {
+	m.textInput.Reset()
+	m.iconInput.Reset()
+	m.minutesInput.Reset()
+	m.formErr = ""
+	m.focusInput(0)
+	m.textInput.Blur()
+}
+
 func (m SubjectSelectModel) View() string {
 	title := TitleStyle.Render("Choose Your Focus")
 
@@ -187,23 +401,41 @@ func (m SubjectSelectModel) View() string {
 
 func (m SubjectSelectModel) renderAddForm(title string) string {
 	form := fmt.Sprintf(
-		"Name:\n%s\n\nIcon:\n%s",
+		"Name:\n%s\n\nIcon:\n%s\n\nMinutes:\n%s",
 		m.textInput.View(),
 		m.iconInput.View(),
+		m.minutesInput.View(),
 	)
 
+	if m.formErr != "" {
+		form += "\n\n" + WarningStyle.Render(m.formErr)
+	}
+
 	help := HelpStyle.Render("tab switch field • enter next/submit • esc cancel")
 
 	return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, form, help)
 }
 
+// sessionCount formats a subject's completed sessions, with a dash for none
+func sessionCount(n int) string {
+	if n == 0 {
+		return "—"
+	}
+	return strconv.Itoa(n)
+}
+
 func (m SubjectSelectModel) renderList(title string) string {
 	if len(m.subjects) == 0 {
 		empty := NormalStyle.Render("No subjects yet. Press 'a' to add one.")
-		help := HelpStyle.Render("a add subject • esc/q back to menu")
+		help := HelpStyle.Render("a add subject • v show archived • esc/q back to menu")
 		return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, empty, help)
 	}
 
+	nameWidth := 0
+	for _, s := range m.subjects {
+		nameWidth = max(nameWidth, lipgloss.Width(s.Name))
+	}
+
 	var list string
 	for i, s := range m.subjects {
 		cursor := "  "
@@ -212,11 +444,31 @@ func (m SubjectSelectModel) renderList(title string) string {
 			cursor = "▸ "
 			style = SelectedStyle
 		}
-		icon := IconStyle.Render(s.Icon)
-		list += fmt.Sprintf("%s%s%s\n", cursor, icon, style.Render(s.Name))
+		icon := renderIcon(s.Icon)
+		minutes := ""
+		if s.DefaultDuration > 0 {
+			minutes = HelpStyle.Render(fmt.Sprintf(" %dm", s.DefaultDuration))
+		}
+		if s.StrictQuotes {
+			minutes += HelpStyle.Render(" (own quotes)")
+		}
+		if s.Archived {
+			style = HelpStyle
+			minutes += HelpStyle.Render(" (archived)")
+		}
+		name := s.Name + strings.Repeat(" ", nameWidth-lipgloss.Width(s.Name))
+		list += fmt.Sprintf("%s%s%s%s%s\n", cursor, icon, style.Render(name), HelpStyle.Render("  ·  "+sessionCount(m.sessions[s.Name])), minutes)
 	}
 
-	help := HelpStyle.Render("↑/↓ navigate • enter select • a add • esc/q back")
+	help := HelpStyle.Render("↑/↓ navigate • shift+↑/↓ move • enter select • a add • o own quotes • x archive • v show archived • esc/q back")
+	if m.showArchived {
+		help = HelpStyle.Render("↑/↓ navigate • enter select • x archive/restore • v hide archived • esc/q back")
+	}
+	if m.clearing {
+		help = WarningStyle.Render(fmt.Sprintf("Delete every quote tagged %s? General quotes are kept. [y] yes • [n] no", m.subjects[m.cursor].Name))
+	} else if m.notice != "" {
+		help = SuccessStyle.Render(m.notice) + "\n  " + help
+	}
 
 	return fmt.Sprintf("\n  %s\n\n%s\n  %s\n", title, list, help)
 }
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+func TestMoveSubjectSavesLatestOrder(t *testing.T) {
+	useTempStore(t)
+
+	for _, name := range []string{"Go", "Latin", "Music"} {
+		if _, err := db.AddSubject(name, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := NewSubjectSelectModel()
+	next, _ := m.Update(m.LoadSubjects()())
+	m = next.(SubjectSelectModel)
+
+	// Music to the top in two quick moves; only one write runs at a time
+	m.cursor = 2
+	next, first := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	next, second := next.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	if first == nil || second != nil {
+		t.Fatal("a move while the order is saving should wait for it")
+	}
+	next, second = next.Update(first())
+	if second == nil {
+		t.Fatal("the waiting move should be saved once the first write finishes")
+	}
+	next.Update(second())
+
+	subjects, err := db.GetAllSubjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range subjects {
+		got = append(got, s.Name)
+	}
+	if len(got) != 3 || got[0] != "Music" || got[1] != "Go" || got[2] != "Latin" {
+		t.Errorf("order = %v, want [Music Go Latin]", got)
+	}
+}
+
+func TestAddSubjectRejectsBadMinutes(t *testing.T) {
+	m := NewSubjectSelectModel()
+	m.adding = true
+	m.textInput.SetValue("Latin")
+	m.focusInput(2)
+
+	for _, minutes := range []string{"0", "-5", "ten"} {
+		m.minutesInput.SetValue(minutes)
+		next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		got := next.(SubjectSelectModel)
+		if cmd != nil || !got.adding || got.formErr == "" {
+			t.Errorf("%q minutes: form closed or saved without an error", minutes)
+		}
+	}
+}
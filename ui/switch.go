@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"Beot/db"
+)
+
+// Switching subject mid-block splits the block into segments. The focus
+// time so far, in whole minutes, is saved straight away as a completed
+// session for the old subject; the countdown carries on for the new one.
+// Leftover seconds move with it, so when the block ends the segments add up
+// to the block's length, and only the last segment is logged with the
+// block's outcome (kept or abandoned) and its pauses. Earlier segments are
+// marked as such so the block counts once wherever sessions are counted;
+// their minutes still add up.
+
+// switchSubjectsLoadedMsg carries the subjects offered by the switcher
+type switchSubjectsLoadedMsg struct {
+	Subjects []db.Subject
+	Err      error
+}
+
+// SegmentSavedMsg reports saving the part of a block spent on the previous subject
+type SegmentSavedMsg struct {
+	Err error
+}
+
+// openSwitcher shows the subject list over the quote; the countdown carries on
+func (m TimerModel) openSwitcher() (TimerModel, tea.Cmd) {
+	m.switching = true
+	m.switchSubjects, m.switchErr = nil, nil
+	m.switchCursor = 0
+	current := m.subjectID
+	return m, func() tea.Msg {
+		subjects, err := db.GetAllSubjects()
+		others := []db.Subject{} // Non-nil once loaded, even when empty
+		for _, s := range subjects {
+			if s.ID.Hex() != current {
+				others = append(others, s)
+			}
+		}
+		return switchSubjectsLoadedMsg{Subjects: others, Err: err}
+	}
+}
+
+// updateSwitcher handles keys while the subject switcher is open. The
+// switch key or cancel closes it, keeping the current subject.
+func (m TimerModel) updateSwitcher(msg tea.KeyMsg) (TimerModel, tea.Cmd) {
+	switch {
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit
+	case key.Matches(msg, TimerKeys.SwitchSubject, TimerKeys.Cancel):
+		m.switching = false
+	case key.Matches(msg, TimerKeys.SwitchUp):
+		if m.switchCursor > 0 {
+			m.switchCursor--
+		}
+	case key.Matches(msg, TimerKeys.SwitchDown):
+		if m.switchCursor < len(m.switchSubjects)-1 {
+			m.switchCursor++
+		}
+	case msg.String() == "enter":
+		if len(m.switchSubjects) > 0 {
+			return m.switchSubject(m.switchSubjects[m.switchCursor])
+		}
+	}
+	return m, nil
+}
+
+// switchSubject saves the segment so far for the current subject and
+// carries on the countdown for the new one
+func (m TimerModel) switchSubject(to db.Subject) (TimerModel, tea.Cmd) {
+	m.switching = false
+
+	var save tea.Cmd
+	elapsed := m.totalSeconds - m.remainingSeconds - m.segmentSeconds
+	if minutes := elapsed / 60; minutes > 0 {
+		subjectID, _ := primitive.ObjectIDFromHex(m.subjectID)
+		name, startedAt := m.subjectName, m.startedAt
+		details := m.currentContent()
+		details.Intention, details.Tags = m.intention, m.tags
+		details.PausedSeconds = m.pausedSeconds()
+		details.Segment = true
+		save = func() tea.Msg {
+			_, err := db.CreateSessionWithDetails(subjectID, name, minutes, db.StatusCompleted, startedAt, details)
+			return SegmentSavedMsg{Err: err}
+		}
+
+		m.segmentSeconds += minutes * 60
+		m.startedAt = time.Now()
+		m.pauses = nil
+		m.pausedBefore = 0
+	}
+
+	m.subjectID, m.subjectName = to.ID.Hex(), to.Name
+	m.strictQuotes = to.StrictQuotes
+	m.pool = nil
+	return m, tea.Batch(save, m.saveActiveCmd(), m.loadPoolCmd())
+}
+
+// renderSwitcher lists the subjects to switch to in place of the quote
+func (m TimerModel) renderSwitcher() string {
+	title := SelectedStyle.Render("Switch subject") + HelpStyle.Render(" — time so far stays with "+m.subjectName)
+	switch {
+	case m.switchErr != nil:
+		return title + "\n\n  " + ErrorStyle.Render("Error: "+m.switchErr.Error())
+	case m.switchSubjects == nil:
+		return title + "\n\n  " + NormalStyle.Render("Loading...")
+	case len(m.switchSubjects) == 0:
+		return title + "\n\n  " + NormalStyle.Render("No other subjects to switch to.")
+	}
+
+	// Keep the list inside the content area, scrolled to the cursor
+	rows := contentLines - 2
+	first := max(0, m.switchCursor-rows+1)
+	end := min(len(m.switchSubjects), first+rows)
+
+	var list []string
+	for i := first; i < end; i++ {
+		s := m.switchSubjects[i]
+		cursor, style := "  ", NormalStyle
+		if i == m.switchCursor {
+			cursor, style = "▸ ", SelectedStyle
+		}
+		list = append(list, fmt.Sprintf("%s%s%s", cursor, renderIcon(s.Icon), style.Render(s.Name)))
+	}
+	return title + "\n\n" + strings.Join(list, "\n")
+}
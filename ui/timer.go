@@ -2,12 +2,19 @@ package ui
 
 import (
 	"fmt"
+	"math/rand"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"Beot/alert"
 	"Beot/db"
+	"Beot/notify"
 )
 
 // Timer messages
@@ -23,16 +30,82 @@ type TimerCompleteMsg struct {
 	SubjectName string // Subject name for display
 	Duration    int    // Duration in minutes
 	StartedAt   time.Time
+	Details     db.SessionDetails // Quote/poem visible at the end, time paused
+	OnBreak     bool              // A break follows the completed screen; come back to the timer view
 }
 
+// CompletionAcknowledgedMsg is sent when a key is pressed on the completed
+// screen; the session itself was saved when the timer ran out
+type CompletionAcknowledgedMsg struct{}
+
+// completionSummary is the progress shown on the completed screen once the
+// stats have been reloaded with the new session
+type completionSummary struct {
+	streak       int
+	todayMinutes int
+}
+
+// timerPhase is the part of the Pomodoro cycle the timer is counting down
+type timerPhase int
+
+const (
+	phaseFocus timerPhase = iota
+	phaseShortBreak
+	phaseLongBreak
+)
+
+// extendMinutes is how much time + adds to the running block
+const extendMinutes = 5
+
+// cancelGrace is how long after starting esc backs out of a mis-chosen
+// subject without logging anything
+const cancelGrace = 10 * time.Second
+
 // DisplayMode determines what content is shown during the timer
 type DisplayMode int
 
 const (
 	DisplayModeQuotes DisplayMode = iota
 	DisplayModePoems
+	DisplayModeBoth // Alternates randomly between quotes and poems
 )
 
+// String returns the name used when persisting the display mode
+func (d DisplayMode) String() string {
+	switch d {
+	case DisplayModePoems:
+		return "poems"
+	case DisplayModeBoth:
+		return "both"
+	default:
+		return "quotes"
+	}
+}
+
+// ParseDisplayMode converts a persisted name back into a DisplayMode
+func ParseDisplayMode(s string) DisplayMode {
+	switch s {
+	case "poems":
+		return DisplayModePoems
+	case "both":
+		return DisplayModeBoth
+	default:
+		return DisplayModeQuotes
+	}
+}
+
+// Next returns the mode after d in the menu cycle
+func (d DisplayMode) Next() DisplayMode {
+	switch d {
+	case DisplayModeQuotes:
+		return DisplayModePoems
+	case DisplayModePoems:
+		return DisplayModeBoth
+	default:
+		return DisplayModeQuotes
+	}
+}
+
 // TimerModel handles the countdown
 type TimerModel struct {
 	totalSeconds     int
@@ -49,9 +122,86 @@ type TimerModel struct {
 	currentPoemSource    string
 	currentPoemLineRef   string
 	displayMode          DisplayMode
+	favoritesOnly        bool       // Restrict quote rotation to favorites
+	strictQuotes         bool       // Prefer quotes tagged with the subject over general ones
+	alert                alert.Mode // Sound played on completion
+	showingPoem          bool       // Which kind of content is currently shown
+	pinned               bool       // Keep the current quote/poem instead of rotating
+	minimal              bool       // Show only the countdown and progress bar
+	deepFocus            bool       // Show only MM:SS, centred, and stop rotating content
+	width, height        int        // Terminal size, for centring the deep focus view
+	quoteMax             int        // Characters of a quote shown before truncating, 0 = no limit
+	expanded             bool       // The current quote is shown in full despite quoteMax
+	emptyHint            bool       // Show the "add some content" hint under the fallback
+	emptyHintShown       bool       // The hint has been shown once already
+	otherSubject         bool       // The current quote is from outside the subject
 	subjectID            string
 	subjectName          string
+	intention            string // Stated goal, shown under the subject and saved with the session
+	tags                 []string
+	summary              *completionSummary // Set once the finished session is counted
 	startedAt            time.Time
+	pauses               []pauseEvent         // Pause/resume log, cleared on reset
+	pausedBefore         int                  // Paused seconds carried over from a recovered session
+	recentQuotes         []primitive.ObjectID // Recently shown, skipped when re-rolling
+	shown                []shownContent       // Content displayed this run, oldest first
+	pool                 *contentPool         // Eligible quotes and poems, nil until loaded
+	shownPos             int                  // Index into shown of what is on screen
+
+	// Mid-block subject switching; see switch.go
+	switching      bool
+	switchSubjects []db.Subject // Every other subject, nil until loaded
+	switchCursor   int
+	switchErr      error
+	segmentSeconds int   // Focus seconds of this block already saved to earlier subjects
+	segmentErr     error // Saving the last segment failed
+	saveErr        error // Saving the finished block failed
+
+	// Break cycle; breaks are off when durations has no break lengths
+	durations     db.Durations
+	focusSeconds  int        // Length of each focus block before any extension
+	phase         timerPhase // Focus block or break
+	blocksDone    int        // Focus blocks completed in this run
+	awaitingFocus bool       // Break finished; waiting for a key to start the next block
+	awaitingBreak bool       // Focus block saved; waiting for a key to start the break
+	todayBlocks   int        // Focus sessions completed today, refreshed after each save
+
+	// Countdown pulse; see pulse.go
+	pulseID   int // Chain driving the pulse, replaced to drop stale ones
+	pulseStep int // Position in the breath, advanced only while running
+}
+
+// contentLines is the fixed height of the quote/poem area in the timer view.
+// Shorter content is padded and longer content truncated so the timer,
+// progress bar and help stay put as the content rotates.
+const contentLines = 12
+
+// activeSaveInterval is how often, in seconds of focus, the running session
+// is saved for crash recovery
+const activeSaveInterval = 30
+
+// recentQuoteLimit is how many recently shown quotes are excluded from rotation
+const recentQuoteLimit = 5
+
+// shownHistoryLimit is how many displayed quotes/poems can be paged back through
+const shownHistoryLimit = 10
+
+// shownContent is one quote or poem as it was displayed
+type shownContent struct {
+	poem          bool
+	quote         string
+	source        string
+	oldEnglish    string
+	modernEnglish string
+	poemSource    string
+	poemLineRef   string
+	otherSubject  bool // A general-pool quote shown because the subject had none
+}
+
+// pauseEvent records one pause; resumedAt is zero while still paused
+type pauseEvent struct {
+	pausedAt  time.Time
+	resumedAt time.Time
 }
 
 // NewTimerModel creates a timer for the given minutes
@@ -59,28 +209,49 @@ func NewTimerModel(minutes int, subjectID, subjectName string) TimerModel {
 	return NewTimerModelWithMode(minutes, subjectID, subjectName, DisplayModeQuotes)
 }
 
+// TimerOptions configures optional timer behaviour
+type TimerOptions struct {
+	DisplayMode   DisplayMode
+	FavoritesOnly bool
+	StrictQuotes  bool // Only the subject's own quotes, general ones only if it has none
+	Alert         alert.Mode
+	Durations     db.Durations // Break lengths and cycle; zero value disables breaks
+	Minimal       bool         // Start in the focus-only view
+	Intention     string       // What the user vowed to accomplish, optional
+	Tags          []string     // Labels saved with the session, optional
+	QuoteMax      int          // Characters of a quote shown before truncating, 0 = no limit
+}
+
+// MinimalTimerChangedMsg is sent when the focus-only timer view is toggled
+type MinimalTimerChangedMsg bool
+
 // NewTimerModelWithMode creates a timer with specified display mode
 func NewTimerModelWithMode(minutes int, subjectID, subjectName string, mode DisplayMode) TimerModel {
-	seconds := minutes * 60
-	prog := progress.New(progress.WithGradient("#4A3728", "#C9A84C"))
-	prog.Width = 80
+	return NewTimerModelWithOptions(minutes, subjectID, subjectName, TimerOptions{DisplayMode: mode})
+}
 
+// NewTimerModelWithOptions creates a timer with the given options
+func NewTimerModelWithOptions(minutes int, subjectID, subjectName string, opts TimerOptions) TimerModel {
+	seconds := minutes * 60
 	m := TimerModel{
 		totalSeconds:     seconds,
 		remainingSeconds: seconds,
 		running:          true,
-		progress:         prog,
-		displayMode:      mode,
+		progress:         NewProgressBar(),
+		displayMode:      opts.DisplayMode,
+		favoritesOnly:    opts.FavoritesOnly,
+		strictQuotes:     opts.StrictQuotes,
+		alert:            opts.Alert,
+		minimal:          opts.Minimal,
+		intention:        opts.Intention,
+		tags:             opts.Tags,
+		durations:        opts.Durations,
+		quoteMax:         opts.QuoteMax,
+		focusSeconds:     seconds,
 		subjectID:        subjectID,
 		subjectName:      subjectName,
 		startedAt:        time.Now(),
-	}
-
-	// Load initial content based on mode
-	if mode == DisplayModePoems {
-		m.loadRandomPoem()
-	} else {
-		m.loadRandomQuote()
+		pulseID:          newPulseID(),
 	}
 
 	return m
@@ -98,102 +269,599 @@ func quoteTickCmd() tea.Cmd {
 	})
 }
 
-func (m *TimerModel) loadRandomQuote() {
-	quote, err := db.GetRandomQuoteForSubject(m.subjectName)
+// ContentLoadedMsg delivers a quote or poem chosen by loadContentCmd
+type ContentLoadedMsg struct {
+	Content shownContent
+	QuoteID primitive.ObjectID // Zero for poems and fallback content
+	Found   bool               // False only when there was no matching content at all
+}
+
+// contentPool holds the subject's quotes and poems, loaded once per timer so
+// rotations are instant and survive the database dropping out mid-session
+type contentPool struct {
+	quotes []db.Quote
+	poems  []db.Poem
+}
+
+// ContentPoolLoadedMsg delivers the quotes and poems a timer rotates through
+type ContentPoolLoadedMsg struct {
+	Quotes []db.Quote
+	Poems  []db.Poem
+	Err    error
+}
+
+// loadPoolCmd fetches every quote and poem eligible for the subject
+func (m TimerModel) loadPoolCmd() tea.Cmd {
+	subject := m.subjectName
+	return func() tea.Msg {
+		quotes, err := db.GetQuotesForSubject(subject)
+		if err != nil {
+			return ContentPoolLoadedMsg{Err: err}
+		}
+		poems, err := db.GetPoemsForSubject(subject)
+		return ContentPoolLoadedMsg{Quotes: quotes, Poems: poems, Err: err}
+	}
+}
+
+// contentRequest is what choosing content needs from the model, copied out
+// so the query can run off the event loop
+type contentRequest struct {
+	mode          DisplayMode
+	subject       string
+	strict        bool
+	favoritesOnly bool
+	exclude       []primitive.ObjectID
+	pool          *contentPool // Chosen from in memory when set, else queried
+}
+
+// randomQuote picks a quote passing the filter from the pool, or from the
+// database when there is no pool
+func (req contentRequest) randomQuote(f db.QuoteFilter) (*db.Quote, error) {
+	if req.pool == nil {
+		return db.GetRandomQuoteMatching(f)
+	}
+	var matches []db.Quote
+	for _, q := range req.pool.quotes {
+		if f.Matches(q) {
+			matches = append(matches, q)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	return &matches[rand.Intn(len(matches))], nil
+}
+
+// randomPoem picks a poem from the pool, or from the database when there is no pool
+func (req contentRequest) randomPoem() (*db.Poem, error) {
+	if req.pool == nil {
+		return db.GetRandomPoemForSubject(req.subject)
+	}
+	if len(req.pool.poems) == 0 {
+		return nil, nil
+	}
+	return &req.pool.poems[rand.Intn(len(req.pool.poems))], nil
+}
+
+// fallbackQuote and fallbackPoem are shown when nothing can be loaded
+var (
+	fallbackQuote = shownContent{quote: "Focus on your task."}
+	fallbackPoem  = shownContent{
+		poem:          true,
+		oldEnglish:    "Wyrd oft nereð\nunfǽgne eorl, þonne his ellen déah",
+		modernEnglish: "Fate often saves\nan undoomed man, when his courage holds",
+		poemSource:    "Beowulf",
+		poemLineRef:   "lines 572-573",
+	}
+)
+
+// loadContentCmd picks the next quote or poem in the background, so a slow
+// database never stalls the countdown
+func (m TimerModel) loadContentCmd() tea.Cmd {
+	req := contentRequest{
+		mode:          m.displayMode,
+		subject:       m.subjectName,
+		strict:        m.strictQuotes,
+		favoritesOnly: m.favoritesOnly,
+		exclude:       append([]primitive.ObjectID(nil), m.recentQuotes...),
+		pool:          m.pool,
+	}
+	return func() tea.Msg { return fetchContent(req) }
+}
+
+// fetchContent chooses between a quote and a poem according to the display
+// mode and loads one
+func fetchContent(req contentRequest) ContentLoadedMsg {
+	var poem bool
+	switch req.mode {
+	case DisplayModePoems:
+		poem = true
+	case DisplayModeBoth:
+		poem = rand.Intn(2) == 0
+	}
+
+	msg := fetchKind(req, poem)
+	if !msg.Found && req.mode == DisplayModeBoth {
+		// One pool is empty; try the other before giving up
+		msg = fetchKind(req, !poem)
+	}
+	return msg
+}
+
+// fetchKind loads a poem or a quote
+func fetchKind(req contentRequest, poem bool) ContentLoadedMsg {
+	if poem {
+		return fetchPoem(req)
+	}
+	return fetchQuote(req)
+}
+
+// fetchQuote loads a random quote, falling back to a default line.
+// Found is false only when there are no quotes at all; a database error
+// also falls back but counts as found so no hint is shown. When the subject
+// has no quotes, one from any subject is marked otherSubject.
+func fetchQuote(req contentRequest) ContentLoadedMsg {
+	filter := db.QuoteFilter{
+		Subject:       req.subject,
+		Strict:        req.strict,
+		FavoritesOnly: req.favoritesOnly,
+		ExcludeIDs:    req.exclude,
+	}
+	quote, err := req.randomQuote(filter)
+	if err == nil && quote == nil && len(filter.ExcludeIDs) > 0 {
+		// Every eligible quote was shown recently; allow repeats rather than none
+		filter.ExcludeIDs = nil
+		quote, err = req.randomQuote(filter)
+	}
+	if err == nil && quote == nil && filter.Strict {
+		// The subject has no quotes of its own; general ones beat the fallback line
+		filter.Strict = false
+		quote, err = req.randomQuote(filter)
+	}
+	wider := false
+	if err == nil && quote == nil && filter.Subject != "" {
+		// Nothing for this subject at all; any real quote beats the fallback
+		// line. The pool only holds the subject's quotes, so ask the database.
+		filter.Subject, filter.Strict = "", false
+		quote, err = db.GetRandomQuoteMatching(filter)
+		wider = quote != nil
+	}
 	if err != nil || quote == nil {
-		m.currentQuote = "Focus on your task."
-		m.currentSource = ""
-		return
+		return ContentLoadedMsg{Content: fallbackQuote, Found: err != nil}
+	}
+	return ContentLoadedMsg{
+		Content: shownContent{quote: quote.Text, source: quote.Source, otherSubject: wider},
+		QuoteID: quote.ID,
+		Found:   true,
 	}
-	m.currentQuote = quote.Text
-	m.currentSource = quote.Source
 }
 
-func (m *TimerModel) loadRandomPoem() {
-	poem, err := db.GetRandomPoem()
+// fetchPoem loads a random poem, falling back to a default passage.
+// Like fetchQuote, Found is false only when there are no poems.
+func fetchPoem(req contentRequest) ContentLoadedMsg {
+	poem, err := req.randomPoem()
 	if err != nil || poem == nil {
-		// Fallback to a default passage
-		m.currentOldEnglish = "Wyrd oft nereð\nunfǽgne eorl, þonne his ellen déah"
-		m.currentModernEnglish = "Fate often saves\nan undoomed man, when his courage holds"
-		m.currentPoemSource = "Beowulf"
-		m.currentPoemLineRef = "lines 572-573"
+		return ContentLoadedMsg{Content: fallbackPoem, Found: err != nil}
+	}
+	return ContentLoadedMsg{
+		Content: shownContent{
+			poem:          true,
+			oldEnglish:    poem.OldEnglish,
+			modernEnglish: poem.ModernEnglish,
+			poemSource:    poem.Source,
+			poemLineRef:   poem.LineRef,
+		},
+		Found: true,
+	}
+}
+
+// rememberQuote records a shown quote, keeping only the most recent few
+func (m *TimerModel) rememberQuote(id primitive.ObjectID) {
+	recent := append([]primitive.ObjectID{id}, m.recentQuotes...)
+	if len(recent) > recentQuoteLimit {
+		recent = recent[:recentQuoteLimit]
+	}
+	m.recentQuotes = recent
+}
+
+// applyContent puts freshly loaded content on screen
+func (m *TimerModel) applyContent(msg ContentLoadedMsg) {
+	if !msg.QuoteID.IsZero() {
+		m.rememberQuote(msg.QuoteID)
+	}
+	m.shown = append(m.shown, msg.Content)
+	if len(m.shown) > shownHistoryLimit {
+		m.shown = m.shown[len(m.shown)-shownHistoryLimit:]
+	}
+	m.showEntry(len(m.shown) - 1)
+
+	// Point the user at the quotes screen the first time nothing is found
+	m.emptyHint = !msg.Found && !m.emptyHintShown
+	if m.emptyHint {
+		m.emptyHintShown = true
+	}
+}
+
+// browsing reports whether an older entry is on screen; auto-rotation waits
+// until the newest is reached again
+func (m TimerModel) browsing() bool {
+	return m.shownPos < len(m.shown)-1
+}
+
+// showEntry displays the history entry at pos
+func (m *TimerModel) showEntry(pos int) {
+	m.shownPos = pos
+	e := m.shown[pos]
+	m.showingPoem = e.poem
+	m.currentQuote, m.currentSource = e.quote, e.source
+	m.currentOldEnglish, m.currentModernEnglish = e.oldEnglish, e.modernEnglish
+	m.currentPoemSource, m.currentPoemLineRef = e.poemSource, e.poemLineRef
+	m.otherSubject = e.otherSubject
+	m.emptyHint = false
+	m.expanded = false
+}
+
+// currentContent describes the quote or poem currently on screen
+func (m TimerModel) currentContent() db.SessionDetails {
+	if m.showingPoem {
+		ref := m.currentPoemSource
+		if m.currentPoemLineRef != "" {
+			ref += ", " + m.currentPoemLineRef
+		}
+		return db.SessionDetails{PoemRef: ref}
+	}
+	return db.SessionDetails{QuoteText: m.currentQuote, QuoteSource: m.currentSource}
+}
+
+// pause stops the countdown and logs when it happened
+func (m *TimerModel) pause() {
+	if !m.running {
 		return
 	}
-	m.currentOldEnglish = poem.OldEnglish
-	m.currentModernEnglish = poem.ModernEnglish
-	m.currentPoemSource = poem.Source
-	m.currentPoemLineRef = poem.LineRef
+	m.running = false
+	m.pauses = append(m.pauses, pauseEvent{pausedAt: time.Now()})
 }
 
-func (m *TimerModel) loadRandomContent() {
-	if m.displayMode == DisplayModePoems {
-		m.loadRandomPoem()
-	} else {
-		m.loadRandomQuote()
+// resume restarts the countdown, closing the open pause entry
+func (m *TimerModel) resume() {
+	m.running = true
+	if n := len(m.pauses); n > 0 && m.pauses[n-1].resumedAt.IsZero() {
+		m.pauses[n-1].resumedAt = time.Now()
+	}
+}
+
+// pausedSeconds totals the time spent paused, counting an open pause up to now
+func (m TimerModel) pausedSeconds() int {
+	total := time.Duration(m.pausedBefore) * time.Second
+	for _, p := range m.pauses {
+		end := p.resumedAt
+		if end.IsZero() {
+			end = time.Now()
+		}
+		total += end.Sub(p.pausedAt)
+	}
+	return int(total.Seconds())
+}
+
+// completeCmd reports the end of the session to the app
+func (m TimerModel) completeCmd(completed bool) tea.Cmd {
+	msg := TimerCompleteMsg{
+		Completed:   completed,
+		SubjectID:   m.subjectID,
+		SubjectName: m.subjectName,
+		Duration:    (m.totalSeconds - m.segmentSeconds) / 60,
+		StartedAt:   m.startedAt,
+		Details:     m.currentContent(),
+	}
+	msg.Details.PausedSeconds = m.pausedSeconds()
+	msg.Details.Intention = m.intention
+	msg.Details.Tags = m.tags
+	msg.OnBreak = completed && m.breaksEnabled()
+	return func() tea.Msg { return msg }
+}
+
+// breaksEnabled reports whether focus blocks are followed by breaks
+func (m TimerModel) breaksEnabled() bool {
+	return m.durations.ShortBreak > 0 && m.durations.LongBreak > 0
+}
+
+// cycleBlock returns the focus block within the current cycle, counting
+// from 1: the one running, or during a break the one just finished
+func (m TimerModel) cycleBlock() (block, of int) {
+	of = m.durations.Cycle
+	if of < 1 {
+		of = 1
+	}
+	done := m.blocksDone
+	if m.onBreak() {
+		done--
+	}
+	return done%of + 1, of
+}
+
+// cycleLabel renders progress through the cycle, e.g. "Block 2 of 4"
+func (m TimerModel) cycleLabel() string {
+	block, of := m.cycleBlock()
+	return fmt.Sprintf("Block %d of %d", block, of)
+}
+
+// breakMomentum renders today's tally and the cycle position for the break
+// screen, e.g. "🍅 ×3 today · block 3/4"
+func (m TimerModel) breakMomentum() string {
+	block, of := m.cycleBlock()
+	if m.todayBlocks == 0 {
+		return fmt.Sprintf("block %d/%d", block, of)
+	}
+	return fmt.Sprintf("🍅 ×%d today · block %d/%d", m.todayBlocks, block, of)
+}
+
+// cancellable reports whether the first block has only just started, so
+// leaving should not count as abandoning it
+func (m TimerModel) cancellable() bool {
+	return m.phase == phaseFocus && m.blocksDone == 0 && m.segmentSeconds == 0 && time.Since(m.startedAt) < cancelGrace
+}
+
+// onBreak reports whether a break is being counted down
+func (m TimerModel) onBreak() bool {
+	return m.phase != phaseFocus
+}
+
+// setLength restarts the countdown with the given number of seconds
+func (m *TimerModel) setLength(seconds int) {
+	m.totalSeconds = seconds
+	m.remainingSeconds = seconds
+	m.running = true
+	m.pauses = nil
+	m.pausedBefore = 0
+	m.segmentSeconds = 0
+	m.tickID++
+}
+
+// finishPhase handles the countdown reaching zero
+func (m TimerModel) finishPhase() (TimerModel, tea.Cmd) {
+	alert.Play(m.alert)
+
+	if m.onBreak() {
+		go notify.BreakComplete()
+		if m.durations.AutoStartWork {
+			return m.startFocus()
+		}
+		m.awaitingFocus = true
+		return m, nil
+	}
+
+	go notify.SessionComplete(m.subjectName)
+	// The completed screen waits for a key; any break begins after it
+	m.running, m.remainingSeconds = false, 0
+	m.summary = nil
+	return m, m.completeCmd(true)
+}
+
+// completed reports whether the completed screen is showing: a focus block
+// ran out and no key has been pressed since
+func (m TimerModel) completed() bool {
+	return m.phase == phaseFocus && m.remainingSeconds <= 0
+}
+
+// beginBreak leaves the completed screen for the break that follows the
+// block, held for another key if breaks wait for one
+func (m TimerModel) beginBreak() (TimerModel, tea.Cmd) {
+	m.blocksDone++
+	m.phase = phaseShortBreak
+	minutes := m.durations.ShortBreak
+	if m.durations.Cycle > 0 && m.blocksDone%m.durations.Cycle == 0 {
+		m.phase = phaseLongBreak
+		minutes = m.durations.LongBreak
 	}
+	m.setLength(minutes * 60)
+	if m.durations.HoldBreaks {
+		m.running = false
+		m.awaitingBreak = true
+		return m, nil
+	}
+	return m, tea.Batch(tickCmd(m.tickID), m.restartPulse())
+}
+
+// startBreak begins a break that was held for a key press
+func (m TimerModel) startBreak() (TimerModel, tea.Cmd) {
+	m.awaitingBreak = false
+	m.running = true
+	m.tickID++
+	return m, tea.Batch(tickCmd(m.tickID), m.restartPulse())
+}
+
+// startFocus begins the next focus block after (or instead of) a break
+func (m TimerModel) startFocus() (TimerModel, tea.Cmd) {
+	m.phase = phaseFocus
+	m.awaitingFocus = false
+	m.awaitingBreak = false
+	m.startedAt = time.Now()
+	m.setLength(m.focusSeconds)
+	var content tea.Cmd
+	if !m.pinned {
+		content = m.loadContentCmd()
+	}
+	return m, tea.Batch(tickCmd(m.tickID), m.saveActiveCmd(), content, m.restartPulse())
+}
+
+// saveActiveCmd snapshots the focus block in progress so it survives a crash
+func (m TimerModel) saveActiveCmd() tea.Cmd {
+	if m.onBreak() {
+		return nil
+	}
+	subjectID, _ := primitive.ObjectIDFromHex(m.subjectID)
+	snapshot := db.ActiveSession{
+		SubjectID:      subjectID,
+		SubjectName:    m.subjectName,
+		Duration:       (m.totalSeconds - m.segmentSeconds) / 60,
+		ElapsedSeconds: m.totalSeconds - m.segmentSeconds - m.remainingSeconds,
+		PausedSeconds:  m.pausedSeconds(),
+		StartedAt:      m.startedAt,
+		Intention:      m.intention,
+		Tags:           m.tags,
+	}
+	return func() tea.Msg {
+		// Best-effort: a failed snapshot only weakens recovery
+		db.SaveActiveSession(snapshot)
+		return nil
+	}
+}
+
+// resumeFrom continues a focus block recovered after the app exited
+func (m *TimerModel) resumeFrom(a db.ActiveSession) {
+	m.remainingSeconds = max(a.RemainingSeconds(), 1)
+	m.startedAt = a.StartedAt
+	m.pausedBefore = a.PausedSeconds
 }
 
 func (m TimerModel) Init() tea.Cmd {
-	return tea.Batch(tickCmd(m.tickID), quoteTickCmd())
+	return tea.Batch(tickCmd(m.tickID), quoteTickCmd(), m.saveActiveCmd(), m.loadPoolCmd(), m.pulseCmd())
 }
 
 func (m TimerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case tea.KeyMsg:
-		// If timer is complete, any key returns to menu
-		if m.remainingSeconds <= 0 {
-			return m, func() tea.Msg {
-				return TimerCompleteMsg{
-					Completed:   true,
-					SubjectID:   m.subjectID,
-					SubjectName: m.subjectName,
-					Duration:    m.totalSeconds / 60,
-					StartedAt:   m.startedAt,
-				}
+		if m.awaitingFocus {
+			switch {
+			case msg.String() == "ctrl+c":
+				return m, tea.Quit
+			case key.Matches(msg, TimerKeys.GiveUp, TimerKeys.Cancel):
+				return m, func() tea.Msg { return BackToMenuMsg{} }
+			case msg.String() == "enter", key.Matches(msg, TimerKeys.Pause, TimerKeys.SkipBreak):
+				return m.startFocus()
+			}
+			return m, nil
+		}
+
+		if m.awaitingBreak {
+			switch {
+			case msg.String() == "ctrl+c":
+				return m, tea.Quit
+			case key.Matches(msg, TimerKeys.GiveUp, TimerKeys.Cancel):
+				// The focus block is already saved; nothing to abandon
+				return m, func() tea.Msg { return BackToMenuMsg{} }
+			case key.Matches(msg, TimerKeys.SkipBreak):
+				return m.startFocus()
+			case msg.String() == "enter", key.Matches(msg, TimerKeys.Pause):
+				return m.startBreak()
+			}
+			return m, nil
+		}
+
+		// The completed screen waits for a key; the session is already saved
+		if m.completed() {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			acknowledged := func() tea.Msg { return CompletionAcknowledgedMsg{} }
+			if !m.breaksEnabled() {
+				return m, acknowledged
 			}
+			next, cmd := m.beginBreak()
+			return next, tea.Batch(cmd, acknowledged)
 		}
 
 		if m.confirming {
 			switch msg.String() {
 			case "y":
-				return m, func() tea.Msg {
-					return TimerCompleteMsg{
-						Completed:   false, // Abandoned
-						SubjectID:   m.subjectID,
-						SubjectName: m.subjectName,
-						Duration:    m.totalSeconds / 60,
-						StartedAt:   m.startedAt,
-					}
-				}
+				return m, m.completeCmd(false) // Abandoned
 			case "n", "esc":
 				m.confirming = false
-				m.running = true
+				m.resume()
 				m.tickID++
-				return m, tickCmd(m.tickID)
+				return m, tea.Batch(tickCmd(m.tickID), m.restartPulse())
 			}
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "ctrl+c":
+		if m.switching {
+			return m.updateSwitcher(msg)
+		}
+
+		switch {
+		case msg.String() == "ctrl+c":
 			return m, tea.Quit
-		case "q":
+		case key.Matches(msg, TimerKeys.SwitchSubject):
+			// Only a running focus block has time to split
+			if !m.onBreak() && m.running {
+				return m.openSwitcher()
+			}
+			return m, nil
+		case key.Matches(msg, TimerKeys.Cancel):
+			if m.cancellable() {
+				// Nothing is saved; drop the crash-recovery snapshot too
+				return m, func() tea.Msg {
+					db.ClearActiveSession()
+					return BackToMenuMsg{}
+				}
+			}
+		case key.Matches(msg, TimerKeys.GiveUp):
+			if m.onBreak() {
+				// The focus block is already saved; nothing to abandon
+				return m, func() tea.Msg { return BackToMenuMsg{} }
+			}
 			m.confirming = true
-			m.running = false
+			m.pause()
+			return m, nil
+		case key.Matches(msg, TimerKeys.SkipBreak):
+			if m.onBreak() {
+				return m.startFocus()
+			}
+		case key.Matches(msg, TimerKeys.Extend):
+			m.remainingSeconds += extendMinutes * 60
+			m.totalSeconds += extendMinutes * 60
 			return m, nil
-		case " ":
-			m.running = !m.running
+		case key.Matches(msg, TimerKeys.Pause):
 			if m.running {
-				m.tickID++
-				return m, tickCmd(m.tickID)
+				m.pause()
+				return m, nil
+			}
+			m.resume()
+			m.tickID++
+			return m, tea.Batch(tickCmd(m.tickID), m.restartPulse())
+		case key.Matches(msg, TimerKeys.Pin):
+			m.pinned = !m.pinned
+			return m, nil
+		case key.Matches(msg, TimerKeys.Expand):
+			// Shows the whole quote until the next one replaces it
+			m.expanded = true
+			return m, nil
+		case key.Matches(msg, TimerKeys.PrevQuote):
+			if m.shownPos > 0 {
+				m.showEntry(m.shownPos - 1)
 			}
 			return m, nil
-		case "r":
-			m.remainingSeconds = m.totalSeconds
+		case key.Matches(msg, TimerKeys.NextQuote):
+			if m.browsing() {
+				m.showEntry(m.shownPos + 1)
+			}
+			return m, nil
+		case key.Matches(msg, TimerKeys.Minimal):
+			// Display only; the countdown carries on untouched
+			m.minimal = !m.minimal
+			minimal := m.minimal
+			return m, func() tea.Msg {
+				db.SetBoolSetting(db.SettingMinimalTimer, minimal)
+				return MinimalTimerChangedMsg(minimal)
+			}
+		case key.Matches(msg, TimerKeys.DeepFocus):
+			// Display only, like the minimal view; rotation resumes on the way out
+			m.deepFocus = !m.deepFocus
+			if !m.deepFocus {
+				return m, m.restartPulse()
+			}
+			return m, nil
+		case key.Matches(msg, TimerKeys.Reset):
+			// After a switch, earlier subjects' segments are already saved,
+			// so only the current subject's part starts again
+			m.remainingSeconds = m.totalSeconds - m.segmentSeconds
 			m.running = true
+			m.pauses = nil
+			m.pausedBefore = 0
+			m.pinned = false
 			m.tickID++
-			return m, tickCmd(m.tickID)
+			return m, tea.Batch(tickCmd(m.tickID), m.restartPulse())
 		}
 
 	case tickMsg:
@@ -204,26 +872,57 @@ func (m TimerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.remainingSeconds--
 			if m.remainingSeconds <= 0 {
 				m.running = false
-				fmt.Print("\a") // Terminal bell
-				return m, func() tea.Msg {
-					return TimerCompleteMsg{
-						Completed:   true,
-						SubjectID:   m.subjectID,
-						SubjectName: m.subjectName,
-						Duration:    m.totalSeconds / 60,
-						StartedAt:   m.startedAt,
-					}
-				}
+				return m.finishPhase()
+			}
+			if elapsed := m.totalSeconds - m.remainingSeconds; elapsed%activeSaveInterval == 0 {
+				return m, tea.Batch(tickCmd(m.tickID), m.saveActiveCmd())
 			}
 			return m, tickCmd(m.tickID)
 		}
 
+	case pulseTickMsg:
+		if msg.id != m.pulseID || !m.pulsing() {
+			return m, nil // stale or no longer pulsing; the chain ends here
+		}
+		m.pulseStep++
+		return m, m.pulseCmd()
+
 	case quoteTickMsg:
+		// The rotation clock keeps running while paging back; only the
+		// newest entry is replaced
 		if m.running {
-			m.loadRandomContent()
+			if !m.pinned && !m.browsing() && !m.deepFocus {
+				return m, tea.Batch(quoteTickCmd(), m.loadContentCmd())
+			}
 			return m, quoteTickCmd()
 		}
 
+	case switchSubjectsLoadedMsg:
+		m.switchSubjects, m.switchErr = msg.Subjects, msg.Err
+		return m, nil
+
+	case SegmentSavedMsg:
+		m.segmentErr = msg.Err
+		return m, nil
+
+	case ContentPoolLoadedMsg:
+		// Without a pool each rotation queries the database instead
+		if msg.Err == nil {
+			m.pool = &contentPool{quotes: msg.Quotes, poems: msg.Poems}
+		}
+		return m, m.loadContentCmd()
+
+	case ContentLoadedMsg:
+		// Pinning or paging back while the load was in flight keeps what is shown
+		if !m.pinned && !m.browsing() {
+			m.applyContent(msg)
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
 	case progress.FrameMsg:
 		progressModel, cmd := m.progress.Update(msg)
 		m.progress = progressModel.(progress.Model)
@@ -238,7 +937,15 @@ func (m TimerModel) View() string {
 		return m.renderConfirmation()
 	}
 
-	if m.remainingSeconds <= 0 {
+	if m.awaitingFocus {
+		return m.renderBreakOver()
+	}
+
+	if m.awaitingBreak {
+		return m.renderBreakReady()
+	}
+
+	if m.completed() {
 		return m.renderComplete()
 	}
 
@@ -251,27 +958,103 @@ func (m TimerModel) renderTimer() string {
 
 	minutes := m.remainingSeconds / 60
 	seconds := m.remainingSeconds % 60
-	timeDisplay := TimerStyle.Render(fmt.Sprintf("%02d:%02d", minutes, seconds))
+	timeDisplay := m.countdownStyle().Render(fmt.Sprintf("%02d:%02d", minutes, seconds))
+
+	// The switcher needs the full view, so it shows even from the focus-only views
+	if m.deepFocus && !m.switching {
+		return m.renderDeepFocus(minutes, seconds)
+	}
+	if m.minimal && !m.switching {
+		return m.renderMinimal(minutes, seconds, percent)
+	}
 
 	status := StatusStyle.Render(fmt.Sprintf("Focus Time: %s", m.subjectName))
+	if len(m.tags) > 0 {
+		status += "  " + HelpStyle.Render("#"+strings.Join(m.tags, " #"))
+	}
+	switch m.phase {
+	case phaseShortBreak:
+		status = StatusStyle.Render("Short Break — rest your eyes")
+	case phaseLongBreak:
+		status = StatusStyle.Render("Long Break — step away for a while")
+	}
 	if !m.running && m.remainingSeconds > 0 {
 		status = StatusStyle.Render("Paused")
 	} else if m.remainingSeconds <= 0 {
 		status = StatusStyle.Render("Complete!")
 	}
 
+	if m.breaksEnabled() && m.remainingSeconds > 0 {
+		if m.onBreak() {
+			status += "  " + HelpStyle.Render(m.breakMomentum())
+		} else {
+			status += "  " + HelpStyle.Render(m.cycleLabel())
+		}
+	}
+	if m.pinned {
+		status += "  " + HelpStyle.Render("📌 pinned")
+	}
+	if m.browsing() {
+		status += "  " + HelpStyle.Render(fmt.Sprintf("◀ %d of %d", m.shownPos+1, len(m.shown)))
+	}
+	if m.segmentErr != nil {
+		status += "\n  " + ErrorStyle.Render("Could not save the time before switching: "+m.segmentErr.Error())
+	}
+	if m.saveErr != nil {
+		status += "\n  " + ErrorStyle.Render(saveErrText(m.saveErr))
+	}
+	if m.intention != "" {
+		if m.onBreak() {
+			status += "\n  " + SuccessStyle.Render(fmt.Sprintf(T("timer.vow.kept"), m.intention))
+		} else {
+			status += "\n  " + QuoteStyle.UnsetWidth().UnsetMarginLeft().Render("Vow: "+m.intention)
+		}
+	}
+
 	progressBar := m.progress.ViewAs(percent)
-	help := HelpStyle.Render("Spacebar to pause/resume • r reset • q quit")
+	k := TimerKeys
+	help := HelpStyle.Render(fmt.Sprintf("%s pause/resume • %s 5 min • %s/%s quotes • %s pin • %s expand • %s switch subject • %s minimal • %s deep focus • %s reset • ? help • %s quit",
+		shortKey(k.Pause), shortKey(k.Extend), shortKey(k.PrevQuote), shortKey(k.NextQuote), shortKey(k.Pin), shortKey(k.Expand),
+		shortKey(k.SwitchSubject), shortKey(k.Minimal), shortKey(k.DeepFocus), shortKey(k.Reset), shortKey(k.GiveUp)))
+	if m.onBreak() {
+		help = HelpStyle.Render(fmt.Sprintf("%s pause/resume • %s skip break • %s 5 min • %s minimal • ? help • %s back to menu",
+			shortKey(k.Pause), shortKey(k.SkipBreak), shortKey(k.Extend), shortKey(k.Minimal), shortKey(k.GiveUp)))
+	} else if m.switching {
+		help = HelpStyle.Render(fmt.Sprintf("%s/%s choose • enter switch • %s keep %s",
+			shortKey(k.SwitchUp), shortKey(k.SwitchDown), shortKey(k.Cancel), m.subjectName))
+	} else if m.cancellable() {
+		help = HelpStyle.Render(fmt.Sprintf("%s wrong subject? back out, nothing is logged • %s pause/resume • ? help • %s quit",
+			shortKey(k.Cancel), shortKey(k.Pause), shortKey(k.GiveUp)))
+	}
 
 	header := RenderHeader()
 
 	// Render content based on display mode
 	var content string
-	if m.displayMode == DisplayModePoems {
+	if m.showingPoem {
 		content = RenderPoem(m.currentOldEnglish, m.currentModernEnglish, m.currentPoemSource, m.currentPoemLineRef)
 	} else {
-		content = RenderQuote(m.currentQuote, m.currentSource)
+		limit := m.quoteMax
+		if m.expanded {
+			limit = 0
+		}
+		content = RenderQuoteLimited(m.currentQuote, m.currentSource, limit, "e")
+	}
+	if m.emptyHint {
+		hint := "No quotes yet — add some from the menu"
+		if m.showingPoem {
+			hint = "No poems yet — run the seed command to add some"
+		}
+		content += "\n\n" + HelpStyle.Render(hint)
+	} else if m.otherSubject && !m.showingPoem {
+		hint := fmt.Sprintf("No quotes for %s yet — showing one from another subject", m.subjectName)
+		content += "\n\n" + HelpStyle.Render(hint)
+	}
+	if m.switching {
+		// The list replaces the quote, hints and all
+		content = m.renderSwitcher()
 	}
+	content = fitLines(content, contentLines)
 
 	return fmt.Sprintf(
 		"\n  %s\n\n  %s\n\n  %s\n\n  %s\n\n  %s  %s\n\n  %s\n",
@@ -285,30 +1068,123 @@ func (m TimerModel) renderTimer() string {
 	)
 }
 
+// renderMinimal is the focus-only view: a large countdown and the progress bar,
+// for running Beot in a small side pane
+func (m TimerModel) renderMinimal(minutes, seconds int, percent float64) string {
+	hint := "m full view"
+	if !m.running {
+		hint = "paused • " + hint
+	} else if m.onBreak() {
+		hint = "break • " + m.breakMomentum() + " • " + hint
+	}
+	bigTime := strings.ReplaceAll(RenderBigTime(minutes, seconds), "\n", "\n  ")
+	return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n",
+		bigTime,
+		m.progress.ViewAs(percent),
+		HelpStyle.Render(hint),
+	)
+}
+
+// renderDeepFocus is nothing but the countdown, centred on an otherwise
+// empty screen. Before the terminal size is known it sits top left.
+func (m TimerModel) renderDeepFocus(minutes, seconds int) string {
+	countdown := m.countdownStyle().Render(fmt.Sprintf("%02d:%02d", minutes, seconds))
+	if m.width == 0 || m.height == 0 {
+		return "\n  " + countdown + "\n"
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, countdown)
+}
+
+// fitLines pads s with blank lines, or truncates it to n-1 lines and an
+// ellipsis line, so it is exactly n lines tall
+func fitLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = append(lines[:n-1], "…")
+	}
+	for len(lines) < n {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m TimerModel) renderConfirmation() string {
-	title := ErrorStyle.Render("Give up?")
-	message := "This will be logged as abandoned 💀"
-	help := HelpStyle.Render("[y] yes, abandon • [n] no, continue")
+	title := ErrorStyle.Render(T("timer.giveup.title"))
+	message := T("timer.giveup.message")
+	help := HelpStyle.Render(T("timer.giveup.help"))
 
 	return fmt.Sprintf("\n  %s\n\n  %s\n\n  %s\n", title, message, help)
 }
 
+func (m TimerModel) renderBreakOver() string {
+	title := SuccessStyle.Render(T("timer.breakover.title"))
+
+	message := NormalStyle.Render(fmt.Sprintf(T("timer.breakover.body"), m.cycleLabel(), m.blocksDone))
+
+	subject := StatusStyle.Render(fmt.Sprintf(T("timer.subject"), m.subjectName))
+
+	content := fmt.Sprintf(
+		"%s\n\n%s\n\n%s\n\n%s",
+		title,
+		message,
+		subject,
+		HelpStyle.Render(fmt.Sprintf(T("timer.breakover.help"), shortKey(TimerKeys.GiveUp))),
+	)
+
+	return "\n" + BoxStyle.Render(content) + "\n"
+}
+
+// renderBreakReady asks for a key before a held break starts
+func (m TimerModel) renderBreakReady() string {
+	title := SuccessStyle.Render(T("timer.complete.title"))
+
+	message := NormalStyle.Render(fmt.Sprintf(T("timer.breakready.body"), m.cycleLabel(), m.blocksDone, m.totalSeconds/60))
+	if m.todayBlocks > 0 {
+		message += "\n" + HelpStyle.Render(m.breakMomentum())
+	}
+	if m.intention != "" {
+		message += "\n\n" + SuccessStyle.Render(fmt.Sprintf(T("timer.vow.kept"), m.intention))
+	}
+
+	subject := StatusStyle.Render(fmt.Sprintf(T("timer.subject"), m.subjectName))
+
+	content := fmt.Sprintf(
+		"%s\n\n%s\n\n%s\n\n%s",
+		title,
+		message,
+		subject,
+		HelpStyle.Render(fmt.Sprintf(T("timer.breakready.help"), shortKey(TimerKeys.SkipBreak), shortKey(TimerKeys.GiveUp))),
+	)
+
+	return "\n" + BoxStyle.Render(content) + "\n"
+}
+
 func (m TimerModel) renderComplete() string {
-	title := SuccessStyle.Render("Your vow is kept.")
+	title := SuccessStyle.Render(T("timer.complete.title"))
 
-	message := NormalStyle.Render(fmt.Sprintf(
-		"You held to your word for %d minutes.\nYour honour remains unbroken.",
-		m.totalSeconds/60,
-	))
+	message := NormalStyle.Render(fmt.Sprintf(T("timer.complete.body"), m.totalSeconds/60))
+	if m.intention != "" {
+		message += "\n\n" + SuccessStyle.Render(fmt.Sprintf(T("timer.vow.kept"), m.intention))
+	}
 
-	subject := StatusStyle.Render(fmt.Sprintf("Subject: %s", m.subjectName))
+	subject := StatusStyle.Render(fmt.Sprintf(T("timer.subject"), m.subjectName))
+	if s := m.summary; s != nil {
+		progress := fmt.Sprintf(T("timer.complete.today"), formatMinutes(s.todayMinutes))
+		if s.streak > 0 {
+			progress = fmt.Sprintf(T("menu.streak"), s.streak) + " · " + progress
+		}
+		subject += "\n" + StreakStyle.Render(progress)
+	}
+	if m.saveErr != nil {
+		subject += "\n" + ErrorStyle.Render(saveErrText(m.saveErr))
+	}
 
 	content := fmt.Sprintf(
 		"%s\n\n%s\n\n%s\n\n%s",
 		title,
 		message,
 		subject,
-		HelpStyle.Render("Press any key to continue"),
+		HelpStyle.Render(T("timer.complete.help")),
 	)
 
 	return "\n" + BoxStyle.Render(content) + "\n"
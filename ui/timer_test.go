@@ -0,0 +1,600 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"Beot/alert"
+	"Beot/db"
+	"Beot/notify"
+)
+
+func TestFitLines(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"pads short content", "a\nb", "a\nb\n\n"},
+		{"keeps exact height", "a\nb\nc\nd", "a\nb\nc\nd"},
+		{"truncates long content", "a\nb\nc\nd\ne\nf", "a\nb\nc\n…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fitLines(tt.in, 4)
+			if got != tt.want {
+				t.Errorf("fitLines(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if n := strings.Count(got, "\n") + 1; n != 4 {
+				t.Errorf("got %d lines, want 4", n)
+			}
+		})
+	}
+}
+
+func TestBreakCycle(t *testing.T) {
+	disableNotifications(t)
+	m := NewTimerModelWithOptions(1, "", "Go", TimerOptions{
+		Alert:     alert.ModeNone,
+		Durations: db.Durations{Work: 1, ShortBreak: 5, LongBreak: 15, Cycle: 2},
+	})
+
+	// The completed screen comes first; the break waits for a key
+	m, _ = m.finishPhase()
+	if !m.completed() || m.running || !strings.Contains(m.View(), "Your vow is kept") {
+		t.Fatalf("a finished block should hold the completed screen, phase %d", m.phase)
+	}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(TimerModel)
+	if m.phase != phaseShortBreak || m.totalSeconds != 5*60 {
+		t.Fatalf("after block 1: phase %d, total %ds; want short break of 300s", m.phase, m.totalSeconds)
+	}
+
+	m, _ = m.startFocus()
+	if m.phase != phaseFocus || m.totalSeconds != 60 {
+		t.Fatalf("after skip: phase %d, total %ds; want focus of 60s", m.phase, m.totalSeconds)
+	}
+
+	m = finishBlock(t, m)
+	if m.phase != phaseLongBreak || m.totalSeconds != 15*60 {
+		t.Fatalf("after block 2: phase %d, total %ds; want long break of 900s", m.phase, m.totalSeconds)
+	}
+
+	m, _ = m.finishPhase()
+	if !m.awaitingFocus {
+		t.Error("finished break should wait for the next block")
+	}
+}
+
+func TestAutoStartSettings(t *testing.T) {
+	disableNotifications(t)
+	m := NewTimerModelWithOptions(1, "", "Go", TimerOptions{
+		Alert:     alert.ModeNone,
+		Durations: db.Durations{Work: 1, ShortBreak: 5, LongBreak: 15, Cycle: 4, HoldBreaks: true, AutoStartWork: true},
+	})
+
+	// A held break is set up but waits for a key
+	m = finishBlock(t, m)
+	if !m.awaitingBreak || m.running || m.phase != phaseShortBreak {
+		t.Fatalf("after the block: awaiting %v, running %v, phase %d; want a held short break", m.awaitingBreak, m.running, m.phase)
+	}
+	if !strings.Contains(m.View(), "break is ready") {
+		t.Error("a held break should say it is ready")
+	}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(TimerModel)
+	if m.awaitingBreak || !m.running || m.remainingSeconds != 5*60 {
+		t.Fatalf("enter should start the break, got awaiting %v, running %v", m.awaitingBreak, m.running)
+	}
+
+	// The next block starts as soon as the break ends
+	m, _ = m.finishPhase()
+	if m.awaitingFocus || m.phase != phaseFocus || !m.running {
+		t.Errorf("after the break: awaiting %v, phase %d; want the next block running", m.awaitingFocus, m.phase)
+	}
+}
+
+func TestCycleProgress(t *testing.T) {
+	disableNotifications(t)
+	m := NewTimerModelWithOptions(1, "", "Go", TimerOptions{
+		Alert:     alert.ModeNone,
+		Durations: db.Durations{Work: 1, ShortBreak: 5, LongBreak: 15, Cycle: 3},
+	})
+
+	want := []string{"Block 1 of 3", "Block 2 of 3", "Block 3 of 3", "Block 1 of 3"}
+	for i, label := range want {
+		if got := m.cycleLabel(); got != label {
+			t.Errorf("focus block %d: got %q, want %q", i+1, got, label)
+		}
+		m = finishBlock(t, m)
+		if got := m.cycleLabel(); got != label {
+			t.Errorf("break after block %d: got %q, want %q", i+1, got, label)
+		}
+		m, _ = m.startFocus()
+	}
+}
+
+func TestCycleOfOneAlwaysLongBreak(t *testing.T) {
+	disableNotifications(t)
+	m := NewTimerModelWithOptions(1, "", "Go", TimerOptions{
+		Alert:     alert.ModeNone,
+		Durations: db.Durations{Work: 1, ShortBreak: 5, LongBreak: 15, Cycle: 1},
+	})
+
+	for i := 1; i <= 3; i++ {
+		m = finishBlock(t, m)
+		if m.phase != phaseLongBreak {
+			t.Fatalf("after block %d: phase %d, want long break", i, m.phase)
+		}
+		if got := m.cycleLabel(); got != "Block 1 of 1" {
+			t.Errorf("after block %d: got %q, want Block 1 of 1", i, got)
+		}
+		m, _ = m.startFocus()
+	}
+}
+
+func TestEscCancelsOnlyJustStarted(t *testing.T) {
+	m := NewTimerModel(25, "", "Go")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("esc right after starting should back out")
+	}
+
+	m.startedAt = time.Now().Add(-cancelGrace)
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || model.(TimerModel).confirming {
+		t.Error("esc after the grace period should do nothing")
+	}
+}
+
+func TestDeepFocus(t *testing.T) {
+	m := NewTimerModel(25, "", "Go")
+	m.currentQuote = "Wyrd bið ful aræd"
+	m.width, m.height = 40, 9
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = model.(TimerModel)
+	view := m.View()
+	if got := strings.TrimSpace(view); got != "25:00" {
+		t.Errorf("deep focus should show only the time, got %q", got)
+	}
+	lines := strings.Split(view, "\n")
+	row := slices.IndexFunc(lines, func(l string) bool { return strings.Contains(l, "25:00") })
+	if len(lines) != 9 || row < 2 || row > 6 || !strings.HasPrefix(lines[row], "          ") {
+		t.Errorf("the time should sit in the middle of the screen, got %q", view)
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if view := model.(TimerModel).View(); !strings.Contains(view, "Wyrd") {
+		t.Error("pressing d again should bring the quote back")
+	}
+}
+
+func TestPulse(t *testing.T) {
+	defer func(was bool) { ReducedMotion = was }(ReducedMotion)
+	ReducedMotion = false
+
+	m := NewTimerModel(25, "", "Go")
+	model, cmd := m.Update(pulseTickMsg{id: m.pulseID})
+	m = model.(TimerModel)
+	if m.pulseStep != 1 || cmd == nil {
+		t.Fatalf("a pulse tick should advance the step and schedule the next, got step %d", m.pulseStep)
+	}
+	if m.remainingSeconds != 25*60 {
+		t.Errorf("the pulse should leave the countdown alone, %ds remaining", m.remainingSeconds)
+	}
+
+	model, cmd = m.Update(pulseTickMsg{id: m.pulseID - 1})
+	if model.(TimerModel).pulseStep != 1 || cmd != nil {
+		t.Error("a tick from an old chain should be ignored")
+	}
+
+	m.pause()
+	model, cmd = m.Update(pulseTickMsg{id: m.pulseID})
+	if model.(TimerModel).pulseStep != 1 || cmd != nil {
+		t.Error("pausing should hold the pulse still and end its chain")
+	}
+	// Resuming starts a new chain, so a tick from the old one can't double it
+	old := m.pulseID
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m = model.(TimerModel)
+	if !m.running || m.pulseID == old {
+		t.Errorf("resume should start a fresh pulse chain, running %v id %d (was %d)", m.running, m.pulseID, old)
+	}
+
+	m.deepFocus = true
+	if _, cmd := m.Update(pulseTickMsg{id: m.pulseID}); cmd != nil {
+		t.Error("deep focus should end the pulse chain")
+	}
+	m.deepFocus = false
+
+	if pulseColor(0) == pulseColor(pulseSteps/2) {
+		t.Error("the colour should change over a breath")
+	}
+
+	ReducedMotion = true
+	if _, cmd := m.Update(pulseTickMsg{id: m.pulseID}); cmd != nil {
+		t.Error("reduced motion should end the pulse chain")
+	}
+	if m.pulseStep = pulseSteps / 2; m.countdownStyle().GetForeground() != TimerStyle.GetForeground() {
+		t.Error("reduced motion should keep the countdown its usual colour")
+	}
+}
+
+func TestExtendKeepsProgressCoherent(t *testing.T) {
+	m := NewTimerModel(25, "", "Go")
+	m.remainingSeconds = 10 * 60
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m = model.(TimerModel)
+
+	if m.totalSeconds != 30*60 || m.remainingSeconds != 15*60 {
+		t.Errorf("after extend: total %ds, remaining %ds; want 1800s, 900s", m.totalSeconds, m.remainingSeconds)
+	}
+}
+
+func TestEmptyContentHint(t *testing.T) {
+	useTempStore(t)
+
+	m := NewTimerModelWithMode(25, "", "Go", DisplayModeBoth)
+	next, _ := m.Update(m.loadContentCmd()())
+	m = next.(TimerModel)
+	if !m.emptyHint {
+		t.Fatal("empty collections should show the hint")
+	}
+	if !strings.Contains(m.renderTimer(), "yet") {
+		t.Error("hint missing from the timer view")
+	}
+
+	next, _ = m.Update(m.loadContentCmd()())
+	m = next.(TimerModel)
+	if m.emptyHint {
+		t.Error("hint should only show the first time")
+	}
+}
+
+func TestQuoteHistoryPaging(t *testing.T) {
+	useTempStore(t)
+
+	m := NewTimerModel(25, "", "Go")
+	m.applyContent(ContentLoadedMsg{Content: shownContent{quote: "first"}, Found: true})
+	m.applyContent(ContentLoadedMsg{Content: shownContent{quote: "second"}, Found: true})
+
+	left := tea.KeyMsg{Type: tea.KeyLeft}
+	right := tea.KeyMsg{Type: tea.KeyRight}
+
+	next, _ := m.Update(left)
+	m = next.(TimerModel)
+	if m.currentQuote != "first" || !m.browsing() {
+		t.Fatalf("after ←: quote %q, browsing %v; want first, true", m.currentQuote, m.browsing())
+	}
+
+	// Neither a rotation nor a load already in flight may replace the entry
+	// being looked at
+	next, _ = m.Update(quoteTickMsg{})
+	m = next.(TimerModel)
+	next, _ = m.Update(ContentLoadedMsg{Content: shownContent{quote: "third"}, Found: true})
+	m = next.(TimerModel)
+	if m.currentQuote != "first" {
+		t.Errorf("rotation while browsing replaced the quote with %q", m.currentQuote)
+	}
+
+	next, _ = m.Update(right)
+	m = next.(TimerModel)
+	if m.currentQuote != "second" || m.browsing() {
+		t.Errorf("after →: quote %q, browsing %v; want second, false", m.currentQuote, m.browsing())
+	}
+}
+
+func TestRotationUsesContentPool(t *testing.T) {
+	// No store is connected, so anything not served from the pool falls back
+	db.Use(db.MongoStore{})
+
+	m := NewTimerModel(25, "", "Go")
+	quote := db.Quote{Text: "Cached", Subjects: []string{"Go"}}
+	next, cmd := m.Update(ContentPoolLoadedMsg{Quotes: []db.Quote{quote}})
+	m = next.(TimerModel)
+	if m.pool == nil {
+		t.Fatal("pool not kept")
+	}
+
+	next, _ = m.Update(cmd())
+	m = next.(TimerModel)
+	if m.currentQuote != "Cached" {
+		t.Errorf("quote = %q, want the pooled quote", m.currentQuote)
+	}
+}
+
+func TestQuoteFromOtherSubject(t *testing.T) {
+	useTempStore(t)
+
+	if _, err := db.AddQuoteWithSubjects("Festina lente", "Augustus", []string{"Latin"}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewTimerModelWithMode(25, "", "Go", DisplayModeQuotes)
+	next, _ := m.Update(m.loadContentCmd()())
+	m = next.(TimerModel)
+	if m.currentQuote != "Festina lente" {
+		t.Fatalf("want the Latin quote over the fallback, got %q", m.currentQuote)
+	}
+	if m.emptyHint {
+		t.Error("a real quote exists, so the empty hint should not show")
+	}
+	if !strings.Contains(m.renderTimer(), "No quotes for Go yet") {
+		t.Error("should say the quote is from another subject")
+	}
+}
+
+// finishedBlock starts a block the way the app does, with the real default
+// breaks, and runs it out. It returns the app on the completed screen and
+// the command saving the block.
+func finishedBlock(t *testing.T) (AppModel, tea.Cmd) {
+	t.Helper()
+	useTempStore(t)
+	disableNotifications(t)
+	subject, err := db.AddSubject("Go", "🐹")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewAppModel()
+	app.alertMode = alert.ModeNone
+	if app.durations, err = db.GetDurations(); err != nil {
+		t.Fatal(err)
+	}
+	next, _ := app.Update(IntentionSetMsg{Subject: *subject})
+	app = next.(AppModel)
+	app.timer.remainingSeconds = 1
+
+	next, cmd := app.Update(tickMsg{id: app.timer.tickID})
+	next, save := next.(AppModel).Update(cmd())
+	app = next.(AppModel)
+	if app.currentView != TimerViewState || !strings.Contains(app.View(), "Your vow is kept") {
+		t.Fatalf("view = %v, want the completed screen", app.currentView)
+	}
+	return app, save
+}
+
+func TestNaturalCompletionWaitsForKey(t *testing.T) {
+	app, save := finishedBlock(t)
+
+	// Saving happens off the event loop, then the stats reload
+	next, cmd := app.Update(save())
+	next, _ = next.(AppModel).Update(cmd())
+	app = next.(AppModel)
+	if app.currentView != TimerViewState || !strings.Contains(app.View(), "Today: 25m") {
+		t.Errorf("completed screen should show today's total, got %q", app.View())
+	}
+
+	// A key starts the break and moves on to the reflection
+	next, _ = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app = next.(AppModel)
+	if app.timer.phase != phaseShortBreak || !app.timer.running {
+		t.Errorf("after a key: phase %d, running %v; want the short break counting", app.timer.phase, app.timer.running)
+	}
+	next, _ = app.Update(CompletionAcknowledgedMsg{})
+	if view := next.(AppModel).currentView; view != ReflectionViewState {
+		t.Errorf("after a key: view = %v, want ReflectionViewState", view)
+	}
+}
+
+func TestCompletionKeyBeforeSave(t *testing.T) {
+	app, save := finishedBlock(t)
+
+	next, _ := app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	next, _ = next.(AppModel).Update(CompletionAcknowledgedMsg{})
+	next, _ = next.(AppModel).Update(save())
+	if view := next.(AppModel).currentView; view != ReflectionViewState {
+		t.Errorf("a save finishing after the key: view = %v, want ReflectionViewState", view)
+	}
+}
+
+func TestFailedSaveKeepsActiveSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	store, err := db.OpenLocalStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Use(store)
+	t.Cleanup(func() { db.Use(db.MongoStore{}) })
+	started := time.Now().Add(-25 * time.Minute)
+	db.SaveActiveSession(db.ActiveSession{SubjectName: "Go", Duration: 25, StartedAt: started})
+
+	// A directory in place of the data file makes every save fail
+	os.Remove(path)
+	if err := os.MkdirAll(filepath.Join(path, "blocked"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewAppModel()
+	app.timer = NewTimerModel(25, "", "Go")
+	app.timer.remainingSeconds = 0
+	next, save := app.Update(TimerCompleteMsg{Completed: true, SubjectName: "Go", Duration: 25, StartedAt: started})
+	next, _ = next.(AppModel).Update(save())
+	app = next.(AppModel)
+
+	if !strings.Contains(app.View(), "Could not save the session") {
+		t.Errorf("the completed screen should show the save error, got %q", app.View())
+	}
+	if active, _ := db.LoadActiveSession(); active == nil {
+		t.Error("a failed save should keep the active session for recovery")
+	}
+}
+
+func TestBreakBehindReflection(t *testing.T) {
+	for _, tt := range []struct {
+		name                  string
+		after, afterMilestone View
+		counts                bool
+	}{
+		{"break", TimerViewState, MenuViewState, true},
+		{"break after a milestone", MilestoneViewState, TimerViewState, true},
+		{"no break, stale milestone target", MenuViewState, TimerViewState, false},
+	} {
+		app := NewAppModel()
+		app.timer = NewTimerModel(5, "", "Go")
+		app.reflection = NewReflectionModel(primitive.NilObjectID, "Go")
+		app.timer.running = true
+		app.currentView = ReflectionViewState
+		app.afterReflection, app.afterMilestone = tt.after, tt.afterMilestone
+
+		next, _ := app.Update(tickMsg{id: app.timer.tickID})
+		counted := next.(AppModel).timer.remainingSeconds < 5*60
+		if counted != tt.counts {
+			t.Errorf("%s: timer counted %v, want %v", tt.name, counted, tt.counts)
+		}
+	}
+}
+
+func TestSwitchSubjectSplitsBlock(t *testing.T) {
+	useTempStore(t)
+
+	goSubject, err := db.AddSubject("Go", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	music, err := db.AddSubject("Music", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewTimerModel(25, goSubject.ID.Hex(), "Go")
+	m.remainingSeconds -= 150 // 2m30s in
+
+	m, cmd := m.switchSubject(*music)
+	for _, c := range cmd().(tea.BatchMsg) {
+		if c == nil {
+			continue
+		}
+		if saved, ok := c().(SegmentSavedMsg); ok && saved.Err != nil {
+			t.Fatal(saved.Err)
+		}
+	}
+
+	sessions, err := db.GetRecentSessions(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].SubjectName != "Go" || sessions[0].Duration != 2 {
+		t.Fatalf("want 2 minutes saved for Go, got %+v", sessions)
+	}
+	if m.subjectName != "Music" || m.remainingSeconds != 25*60-150 {
+		t.Errorf("countdown should carry on for Music, got %s with %ds left", m.subjectName, m.remainingSeconds)
+	}
+
+	// The leftover 30s go with Music, so the pieces add up to the block
+	done := m.completeCmd(true)().(TimerCompleteMsg)
+	if done.SubjectName != "Music" || done.Duration != 23 {
+		t.Errorf("final piece = %s %dm, want Music 23m", done.SubjectName, done.Duration)
+	}
+	if m.cancellable() {
+		t.Error("a split block has already logged time, so it cannot be backed out of")
+	}
+
+	// Reset restarts Music's part; Go's saved 2 minutes aren't run again
+	m.remainingSeconds -= 300
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = next.(TimerModel)
+	if m.remainingSeconds != 23*60 {
+		t.Errorf("after reset %ds left, want %ds", m.remainingSeconds, 23*60)
+	}
+	if done := m.completeCmd(true)().(TimerCompleteMsg); done.Duration != 23 {
+		t.Errorf("final piece after reset = %dm, want 23m", done.Duration)
+	}
+}
+
+func TestSwitchedBlockCountsOnce(t *testing.T) {
+	useTempStore(t)
+	goSubject, _ := db.AddSubject("Go", "")
+	music, _ := db.AddSubject("Music", "")
+
+	m := NewTimerModel(25, goSubject.ID.Hex(), "Go")
+	m.remainingSeconds -= 150
+	m, cmd := m.switchSubject(*music)
+	for _, c := range cmd().(tea.BatchMsg) {
+		if c != nil {
+			c()
+		}
+	}
+	done := m.completeCmd(true)().(TimerCompleteMsg)
+	if _, err := db.CreateSessionWithDetails(music.ID, done.SubjectName, done.Duration, db.StatusCompleted, done.StartedAt, done.Details); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := db.GetSessionStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalSessions != 1 || stats.CompletedSessions != 1 || stats.TotalMinutes != 25 {
+		t.Errorf("stats = %d sessions, %d completed, %dm; want one 25m block", stats.TotalSessions, stats.CompletedSessions, stats.TotalMinutes)
+	}
+	if bySubject, _ := db.GetSessionsBySubject(); bySubject["Go"] != 0 || bySubject["Music"] != 1 {
+		t.Errorf("sessions by subject = %v, want the block counted once for Music", bySubject)
+	}
+	if minutes, _ := db.GetMinutesBySubject(); minutes["Go"] != 2 || minutes["Music"] != 23 {
+		t.Errorf("minutes by subject = %v, want Go 2 and Music 23", minutes)
+	}
+	byHour, _ := db.GetSessionsByHour()
+	total := 0
+	for _, n := range byHour {
+		total += n
+	}
+	if total != 1 {
+		t.Errorf("hour histogram counts %d sessions, want 1", total)
+	}
+	if longest, _ := db.GetLongestSession(); longest == nil || longest.Segment {
+		t.Errorf("longest session = %+v, want the block's last part", longest)
+	}
+}
+
+func TestBreakMomentum(t *testing.T) {
+	disableNotifications(t)
+	m := NewTimerModelWithOptions(1, "", "Go", TimerOptions{
+		Alert:     alert.ModeNone,
+		Durations: db.Durations{Work: 1, ShortBreak: 5, LongBreak: 15, Cycle: 4},
+	})
+	m = finishBlock(t, m)
+	m, _ = m.startFocus()
+	m = finishBlock(t, m)
+
+	if got := m.breakMomentum(); got != "block 2/4" {
+		t.Errorf("before today's count loads: got %q, want block 2/4", got)
+	}
+	m.todayBlocks = 3
+	if got := m.breakMomentum(); got != "🍅 ×3 today · block 2/4" {
+		t.Errorf("got %q, want 🍅 ×3 today · block 2/4", got)
+	}
+	if view := m.View(); !strings.Contains(view, "🍅 ×3 today · block 2/4") {
+		t.Errorf("the break view should show today's count:\n%s", view)
+	}
+}
+
+// finishBlock runs a focus block out and presses a key on the completed
+// screen, which starts the break that follows
+func finishBlock(t *testing.T, m TimerModel) TimerModel {
+	t.Helper()
+	m, _ = m.finishPhase()
+	if !m.completed() {
+		t.Fatal("a finished block should show the completed screen")
+	}
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return next.(TimerModel)
+}
+
+// disableNotifications turns desktop notifications off until the test ends
+func disableNotifications(t *testing.T) {
+	t.Helper()
+	enabled := notify.Enabled
+	t.Cleanup(func() { notify.Enabled = enabled })
+	notify.Enabled = false
+}
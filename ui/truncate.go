@@ -0,0 +1,18 @@
+package ui
+
+import "github.com/rivo/uniseg"
+
+// truncateText shortens s to max characters, adding "..." when anything was
+// cut. Characters are grapheme clusters, so þ or ǽ is never cut mid-byte and
+// an emoji sequence or a letter with a combining macron is never split.
+func truncateText(s string, max int) string {
+	if uniseg.GraphemeClusterCount(s) <= max {
+		return s
+	}
+	g := uniseg.NewGraphemes(s)
+	end := 0
+	for n := 0; n < max && g.Next(); n++ {
+		_, end = g.Positions()
+	}
+	return s[:end] + "..."
+}
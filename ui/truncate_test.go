@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"Beot/db"
+)
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"short text is kept", "Wyrd bið ful aræd", 50, "Wyrd bið ful aræd"},
+		{"exactly max is kept", "þæt wæs god cyning", 18, "þæt wæs god cyning"},
+		{"cut after a multi-byte letter", "þæt wæs god cyning", 3, "þæt..."},
+		{"cut right before ǽ", "hwǽt", 2, "hw..."},
+		{"cut through ð", "ðēodcyninga", 2, "ðē..."},
+		{"combining macron stays with its letter", "wēard", 2, "wē..."},
+		{"emoji is one character", "🔥🔥🔥", 2, "🔥🔥..."},
+		{"family emoji is not split", "a👨‍👩‍👧b", 2, "a👨‍👩‍👧..."},
+		{"flag is not split", "🇬🇧🇬🇧", 1, "🇬🇧..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateText(tt.in, tt.max)
+			if got != tt.want {
+				t.Errorf("truncateText(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncateText(%q, %d) is not valid UTF-8: %q", tt.in, tt.max, got)
+			}
+		})
+	}
+}
+
+func TestQuoteListTruncatesByCharacter(t *testing.T) {
+	// 49 ASCII letters then þ: byte slicing at 50 would cut þ in half
+	text := strings.Repeat("a", 49) + "þþþ"
+	m := NewQuotesModel()
+	next, _ := m.Update(QuotesLoadedMsg{Quotes: []db.Quote{{Text: text}}})
+	view := next.(QuotesModel).View()
+	if !utf8.ValidString(view) || !strings.Contains(view, strings.Repeat("a", 49)+"þ...") {
+		t.Errorf("the list should cut after the first þ:\n%s", view)
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"Beot/db"
+)
+
+// wyrdFile is written when the clipboard is unavailable
+const wyrdFile = "beot-wyrd.md"
+
+// WyrdSharedMsg reports where the My Wyrd summary was placed
+type WyrdSharedMsg struct {
+	Destination string // "clipboard" or a file path
+	Err         error
+}
+
+// RenderWyrd renders key stats as a compact markdown block for sharing
+func RenderWyrd(stats *db.SessionStats, bySubject map[string]int) string {
+	var b strings.Builder
+
+	b.WriteString("## My Wyrd — Bēot\n\n")
+	b.WriteString(fmt.Sprintf("- ⏱ **%s** of focus\n", formatMinutes(stats.TotalMinutes)))
+	b.WriteString(fmt.Sprintf("- ✓ %d vows kept, 💀 %d broken\n", stats.CompletedSessions, stats.AbandonedSessions))
+	b.WriteString(fmt.Sprintf("- ⚡ %d day streak (longest %d)\n", stats.CurrentStreak, stats.LongestStreak))
+
+	if top := sortSubjectTotals(bySubject); len(top) > 0 {
+		b.WriteString(fmt.Sprintf("- 🏆 Most devoted to **%s** (%d sessions)\n", top[0].name, top[0].total))
+	}
+
+	b.WriteString(fmt.Sprintf("\n_Woven %s_\n", time.Now().Format("2 January 2006")))
+	return b.String()
+}
+
+// formatMinutes formats minutes as "3h 20m" or "45m"
+func formatMinutes(total int) string {
+	if total >= 60 {
+		return fmt.Sprintf("%dh %dm", total/60, total%60)
+	}
+	return fmt.Sprintf("%dm", total)
+}
+
+// shareWyrdCmd copies the My Wyrd summary to the clipboard, falling back to a file
+func shareWyrdCmd() tea.Cmd {
+	return func() tea.Msg {
+		stats, err := db.GetSessionStats()
+		if err != nil {
+			return WyrdSharedMsg{Err: err}
+		}
+		bySubject, err := db.GetSessionsBySubject()
+		if err != nil {
+			return WyrdSharedMsg{Err: err}
+		}
+
+		wyrd := RenderWyrd(stats, bySubject)
+		if err := clipboard.WriteAll(wyrd); err == nil {
+			return WyrdSharedMsg{Destination: "clipboard"}
+		}
+
+		if err := os.WriteFile(wyrdFile, []byte(wyrd), 0o644); err != nil {
+			return WyrdSharedMsg{Err: err}
+		}
+		return WyrdSharedMsg{Destination: wyrdFile}
+	}
+}